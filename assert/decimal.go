@@ -0,0 +1,141 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// RoundingMode is the way a decimal is rounded to a number of decimal places.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero, as `decimal.Round` does.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even digit, as
+	// `decimal.RoundBank` does.
+	RoundHalfEven
+	// RoundDown rounds towards zero, as `decimal.Truncate` does.
+	RoundDown
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+)
+
+func (m RoundingMode) String() string {
+
+	switch m {
+	case RoundHalfUp:
+		return "half up"
+	case RoundHalfEven:
+		return "half even"
+	case RoundDown:
+		return "down"
+	case RoundFloor:
+		return "floor"
+	case RoundCeil:
+		return "ceil"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+func (m RoundingMode) round(d decimal.Decimal, places int32) decimal.Decimal {
+
+	switch m {
+	case RoundHalfEven:
+		return d.RoundBank(places)
+	case RoundDown:
+		return d.Shift(places).Truncate(0).Shift(-places)
+	case RoundFloor:
+		return d.Shift(places).Floor().Shift(-places)
+	case RoundCeil:
+		return d.Shift(places).Ceil().Shift(-places)
+	default:
+		return d.Round(places)
+	}
+}
+
+// DecimalEqualAtScale asserts that `expected` and `actual` are equal once
+// both are rounded half up to `places` decimal places.
+func DecimalEqualAtScale(
+	t *testing.T,
+	expected decimal.Decimal,
+	actual decimal.Decimal,
+	places int32,
+	s ...any,
+) bool {
+
+	e := expected.Round(places)
+	a := actual.Round(places)
+	if e.Equal(a) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf(
+		"Decimals not equal at %d decimal places:\n" +
+		"expected: %s (%s)\n" +
+		"actual  : %s (%s)",
+		places,
+		e.StringFixed(places), expected,
+		a.StringFixed(places), actual,
+	), s...)
+}
+
+// DecimalWithin asserts that `actual` differs from `expected` by no more
+// than `tolerance`.
+func DecimalWithin(
+	t *testing.T,
+	expected decimal.Decimal,
+	actual decimal.Decimal,
+	tolerance decimal.Decimal,
+	s ...any,
+) bool {
+
+	diff := actual.Sub(expected).Abs()
+	if diff.LessThanOrEqual(tolerance) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf(
+		"Decimals differ by more than %s:\n" +
+		"expected  : %s\n" +
+		"actual    : %s\n" +
+		"difference: %s",
+		tolerance,
+		expected,
+		actual,
+		diff,
+	), s...)
+}
+
+// DecimalRoundsTo asserts that `actual`, rounded to `places` decimal places
+// using `mode`, equals `expected`.
+func DecimalRoundsTo(
+	t *testing.T,
+	expected decimal.Decimal,
+	actual decimal.Decimal,
+	places int32,
+	mode RoundingMode,
+	s ...any,
+) bool {
+
+	rounded := mode.round(actual, places)
+	if rounded.Equal(expected) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf(
+		"Decimal does not round (%s) to expected value at %d decimal places:\n" +
+		"expected: %s\n" +
+		"actual  : %s (rounded from %s)",
+		mode,
+		places,
+		expected,
+		rounded.StringFixed(places),
+		actual,
+	), s...)
+}
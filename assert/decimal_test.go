@@ -0,0 +1,123 @@
+package assert_test
+
+import (
+	"testing"
+
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/assert"
+	gotest "github.com/thecodedproject/gotest/util"
+)
+
+func TestDecimalConstructors(t *testing.T) {
+
+	tfyassert.Equal(t, "0.1", gotest.DS("0.1").String())
+	tfyassert.True(t, gotest.DI(125, -2).Equal(gotest.DS("1.25")))
+
+	ds := gotest.DSlice("1", "2.5")
+	tfyassert.Len(t, ds, 2)
+	tfyassert.True(t, ds[1].Equal(gotest.DI(25, -1)))
+
+	dm := gotest.DMap(map[string]string{"a": "0.3"})
+	tfyassert.True(t, dm["a"].Equal(gotest.DS("0.30")))
+}
+
+func TestDecimalEqualAtScale(t *testing.T) {
+
+	testCases := []struct{
+		Name string
+		Expected string
+		Actual string
+		Places int32
+		Equal bool
+	}{
+		{
+			Name: "equal",
+			Expected: "1.23",
+			Actual: "1.23",
+			Places: 2,
+			Equal: true,
+		},
+		{
+			Name: "equal after rounding",
+			Expected: "1.2349",
+			Actual: "1.2301",
+			Places: 2,
+			Equal: true,
+		},
+		{
+			Name: "halves round up",
+			Expected: "1.24",
+			Actual: "1.235",
+			Places: 2,
+			Equal: true,
+		},
+		{
+			Name: "not equal",
+			Expected: "1.23",
+			Actual: "1.236",
+			Places: 2,
+		},
+		{
+			Name: "negative places",
+			Expected: "1200",
+			Actual: "1249",
+			Places: -2,
+			Equal: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			fakeT := testing.T{}
+			tfyassert.Equal(
+				t,
+				test.Equal,
+				assert.DecimalEqualAtScale(&fakeT, gotest.DS(test.Expected), gotest.DS(test.Actual), test.Places),
+			)
+			tfyassert.Equal(t, !test.Equal, fakeT.Failed())
+		})
+	}
+}
+
+func TestDecimalWithin(t *testing.T) {
+
+	fakeT := testing.T{}
+	tfyassert.True(t, assert.DecimalWithin(&fakeT, gotest.DS("1"), gotest.DS("1.01"), gotest.DS("0.01")))
+	tfyassert.True(t, assert.DecimalWithin(&fakeT, gotest.DS("1"), gotest.DS("0.99"), gotest.DS("0.01")))
+	tfyassert.False(t, fakeT.Failed())
+
+	tfyassert.False(t, assert.DecimalWithin(&fakeT, gotest.DS("1"), gotest.DS("1.011"), gotest.DS("0.01")))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestDecimalRoundsTo(t *testing.T) {
+
+	testCases := []struct{
+		Name string
+		Mode assert.RoundingMode
+		Actual string
+		Expected string
+	}{
+		{Name: "half up", Mode: assert.RoundHalfUp, Actual: "2.345", Expected: "2.35"},
+		{Name: "half up negative", Mode: assert.RoundHalfUp, Actual: "-2.345", Expected: "-2.35"},
+		{Name: "half even", Mode: assert.RoundHalfEven, Actual: "2.345", Expected: "2.34"},
+		{Name: "half even odd", Mode: assert.RoundHalfEven, Actual: "2.355", Expected: "2.36"},
+		{Name: "down", Mode: assert.RoundDown, Actual: "2.349", Expected: "2.34"},
+		{Name: "down negative", Mode: assert.RoundDown, Actual: "-2.349", Expected: "-2.34"},
+		{Name: "floor", Mode: assert.RoundFloor, Actual: "-2.341", Expected: "-2.35"},
+		{Name: "ceil", Mode: assert.RoundCeil, Actual: "2.341", Expected: "2.35"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			tfyassert.True(t, assert.DecimalRoundsTo(t, gotest.DS(test.Expected), gotest.DS(test.Actual), 2, test.Mode))
+		})
+	}
+
+	t.Run("fails when rounding differs", func(t *testing.T) {
+		fakeT := testing.T{}
+		tfyassert.False(t, assert.DecimalRoundsTo(&fakeT, gotest.DS("2.35"), gotest.DS("2.345"), 2, assert.RoundHalfEven))
+		tfyassert.True(t, fakeT.Failed())
+	})
+}
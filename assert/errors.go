@@ -0,0 +1,216 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ErrorIsAll asserts that `errors.Is(err, target)` for each of `targets`.
+func ErrorIsAll(t *testing.T, err error, targets ...error) bool {
+
+	var missing []string
+	for _, target := range targets {
+		if !errors.Is(err, target) {
+			missing = append(missing, fmt.Sprintf("%T %s", target, errorString(target)))
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf(
+		"Error is not:\n  %s\n%s",
+		strings.Join(missing, "\n  "),
+		ErrorTree(err),
+	))
+}
+
+// ErrorAsType asserts that an error of type `T` is in the tree of `err`, as
+// found by `errors.As`, and returns it.
+func ErrorAsType[T error](t *testing.T, err error, s ...any) (T, bool) {
+
+	var target T
+	if errors.As(err, &target) {
+		return target, true
+	}
+
+	return target, assert.Fail(t, fmt.Sprintf(
+		"No error of type %s in error\n%s",
+		reflect.TypeOf((*T)(nil)).Elem(),
+		ErrorTree(err),
+	), s...)
+}
+
+// ErrorAsLogicallyEqual asserts that an error of the type of `expected` is in
+// the tree of `err`, as found by `errors.As`, and that it is logically equal
+// to `expected`, as compared by `LogicallyEqual`.
+func ErrorAsLogicallyEqual[T error](t *testing.T, err error, expected T, s ...any) bool {
+
+	actual, ok := ErrorAsType[T](t, err, s...)
+	if !ok {
+		return false
+	}
+	return LogicallyEqual(t, expected, actual, s...)
+}
+
+// ErrorChainMatches asserts that `chain` matches errors along one path of the
+// tree of `err`, walked through `Unwrap() error` and `Unwrap() []error`, in
+// order from the root; errors on the path between matched ones are skipped.
+//
+// An expected error matches an error in the tree if `errors.Is` would stop
+// at it, or if both have the same type and are logically equal, as compared
+// by `LogicallyEqual`.
+func ErrorChainMatches(t *testing.T, err error, chain []error, s ...any) bool {
+
+	if chainMatches(err, chain) {
+		return true
+	}
+
+	expected := make([]string, len(chain))
+	for i, e := range chain {
+		expected[i] = fmt.Sprintf("%T %s", e, errorString(e))
+	}
+	return assert.Fail(t, fmt.Sprintf(
+		"Error chain does not match:\n  %s\n%s",
+		strings.Join(expected, "\n  "),
+		ErrorTree(err),
+	), s...)
+}
+
+// ErrorMatches asserts that the message of `err` matches the regular
+// expression `pattern`.
+func ErrorMatches(t *testing.T, err error, pattern string, s ...any) bool {
+
+	if err == nil {
+		return assert.Fail(t, fmt.Sprintf("Expected an error matching /%s/, got nil", pattern), s...)
+	}
+
+	re, reErr := regexp.Compile(pattern)
+	if reErr != nil {
+		return assert.Fail(t, fmt.Sprintf("Invalid pattern /%s/: %v", pattern, reErr), s...)
+	}
+	if re.MatchString(err.Error()) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf(
+		"Error message does not match /%s/\n%s",
+		pattern,
+		ErrorTree(err),
+	), s...)
+}
+
+// ErrorTree formats the tree of `err`, walked through `Unwrap() error` and
+// `Unwrap() []error`, with the type and message of each error on its own
+// line.
+func ErrorTree(err error) string {
+
+	var b strings.Builder
+	b.WriteString("Error tree:")
+	if err == nil {
+		b.WriteString(" nil")
+		return b.String()
+	}
+	writeErrorTree(&b, err, 1)
+	return b.String()
+}
+
+func writeErrorTree(b *strings.Builder, err error, depth int) {
+
+	fmt.Fprintf(b, "\n%s%T %q", strings.Repeat("  ", depth), err, err.Error())
+	for _, child := range unwrapErrors(err) {
+		writeErrorTree(b, child, depth + 1)
+	}
+}
+
+func chainMatches(err error, chain []error) bool {
+
+	if len(chain) == 0 {
+		return true
+	}
+	if err == nil {
+		return false
+	}
+
+	rest := chain
+	if errorMatches(err, chain[0]) {
+		rest = chain[1:]
+		if len(rest) == 0 {
+			return true
+		}
+	}
+	for _, child := range unwrapErrors(err) {
+		if chainMatches(child, rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorMatches reports whether `errors.Is` would stop at `err` for
+// `target`, or `err` and `target` are of the same type and logically equal.
+func errorMatches(err, target error) bool {
+
+	if reflect.TypeOf(err) == reflect.TypeOf(target) {
+		if reflect.TypeOf(err).Comparable() && err == target {
+			return true
+		}
+		if IsLogicallyEqual(target, err) {
+			return true
+		}
+	}
+	if is, ok := err.(interface{ Is(error) bool }); ok && is.Is(target) {
+		return true
+	}
+	return false
+}
+
+func unwrapErrors(err error) []error {
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if child := u.Unwrap(); child != nil {
+			return []error{child}
+		}
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	}
+	return nil
+}
+
+// errorString returns the message of `err`, or its fields if it cannot
+// produce a message, as an expected error built only for comparison may not.
+func errorString(err error) (msg string) {
+
+	if err == nil {
+		return "nil"
+	}
+	defer func() {
+		if recover() != nil {
+			msg = formatFields(reflect.ValueOf(err))
+		}
+	}()
+	return fmt.Sprintf("%q", err.Error())
+}
+
+func formatFields(v reflect.Value) string {
+
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Sprintf("%v", v)
+	}
+
+	fields := make([]string, v.NumField())
+	for i := range fields {
+		fields[i] = fmt.Sprintf("%s:%v", v.Type().Field(i).Name, v.Field(i))
+	}
+	return "{" + strings.Join(fields, " ") + "}"
+}
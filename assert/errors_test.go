@@ -0,0 +1,188 @@
+package assert_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/assert"
+)
+
+var (
+	errNotFound = errors.New("not found")
+	errTimeout = errors.New("timeout")
+)
+
+type InsufficientFundsError struct {
+	Account string
+	Shortfall decimal.Decimal
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("account %s short by %s", e.Account, e.Shortfall)
+}
+
+type OpError struct {
+	Op string
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+type ValidationError struct {
+	Field string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid " + e.Field
+}
+
+func testErrorTree() error {
+
+	funds := &InsufficientFundsError{Account: "a", Shortfall: decimal.New(150, -2)}
+	return fmt.Errorf("transfer: %w", errors.Join(
+		&OpError{Op: "debit", Err: funds},
+		fmt.Errorf("notify: %w", errTimeout),
+	))
+}
+
+func TestErrorIsAll(t *testing.T) {
+
+	err := fmt.Errorf("a: %w, b: %w", errNotFound, errTimeout)
+	tfyassert.True(t, assert.ErrorIsAll(t, err, errNotFound, errTimeout))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.ErrorIsAll(&fakeT, fmt.Errorf("a: %w", errNotFound), errNotFound, errTimeout))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestErrorAsType(t *testing.T) {
+
+	funds, ok := assert.ErrorAsType[*InsufficientFundsError](t, testErrorTree())
+	tfyassert.True(t, ok)
+	tfyassert.Equal(t, "a", funds.Account)
+
+	var fakeT testing.T
+	_, ok = assert.ErrorAsType[ValidationError](&fakeT, testErrorTree())
+	tfyassert.False(t, ok)
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestErrorAsLogicallyEqual(t *testing.T) {
+
+	tfyassert.True(t, assert.ErrorAsLogicallyEqual(
+		t,
+		testErrorTree(),
+		&InsufficientFundsError{Account: "a", Shortfall: decimal.New(15, -1)},
+	))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.ErrorAsLogicallyEqual(
+		&fakeT,
+		testErrorTree(),
+		&InsufficientFundsError{Account: "a", Shortfall: decimal.New(2, 0)},
+	))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestErrorChainMatches(t *testing.T) {
+
+	testCases := []struct{
+		name string
+		chain []error
+		pass bool
+	}{
+		{
+			name: "empty chain",
+			pass: true,
+		},
+		{
+			name: "sentinel error",
+			chain: []error{errTimeout},
+			pass: true,
+		},
+		{
+			name: "structured error compared logically",
+			chain: []error{&InsufficientFundsError{Account: "a", Shortfall: decimal.New(15, -1)}},
+			pass: true,
+		},
+		{
+			name: "wrapping error not logically equal",
+			chain: []error{
+				&OpError{Op: "debit"},
+				&InsufficientFundsError{Account: "a", Shortfall: decimal.New(15, -1)},
+			},
+		},
+		{
+			name: "errors on the same path compared logically",
+			chain: []error{
+				&OpError{
+					Op: "debit",
+					Err: &InsufficientFundsError{Account: "a", Shortfall: decimal.New(150, -2)},
+				},
+				&InsufficientFundsError{Account: "a", Shortfall: decimal.New(15, -1)},
+			},
+			pass: true,
+		},
+		{
+			name: "errors on different paths",
+			chain: []error{
+				&InsufficientFundsError{Account: "a", Shortfall: decimal.New(15, -1)},
+				errTimeout,
+			},
+		},
+		{
+			name: "errors in the wrong order",
+			chain: []error{
+				errTimeout,
+				testErrorTree(),
+			},
+		},
+		{
+			name: "error not in tree",
+			chain: []error{errNotFound},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+
+			var fakeT testing.T
+			tfyassert.Equal(t, test.pass, assert.ErrorChainMatches(&fakeT, testErrorTree(), test.chain))
+			tfyassert.Equal(t, !test.pass, fakeT.Failed())
+		})
+	}
+}
+
+func TestErrorMatches(t *testing.T) {
+
+	tfyassert.True(t, assert.ErrorMatches(t, testErrorTree(), `^transfer: debit: account \w+ short`))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.ErrorMatches(&fakeT, testErrorTree(), `^debit`))
+	tfyassert.False(t, assert.ErrorMatches(&fakeT, nil, `.*`))
+	tfyassert.False(t, assert.ErrorMatches(&fakeT, errTimeout, `(`))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestErrorTree(t *testing.T) {
+
+	expected := `Error tree:
+  *fmt.wrapError "transfer: debit: account a short by 1.5\nnotify: timeout"
+    *errors.joinError "debit: account a short by 1.5\nnotify: timeout"
+      *assert_test.OpError "debit: account a short by 1.5"
+        *assert_test.InsufficientFundsError "account a short by 1.5"
+      *fmt.wrapError "notify: timeout"
+        *errors.errorString "timeout"`
+	tfyassert.Equal(t, expected, assert.ErrorTree(testErrorTree()))
+	tfyassert.Equal(t, "Error tree: nil", assert.ErrorTree(nil))
+}
@@ -0,0 +1,288 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// Placeholders which, as string values in the expected document of
+// `JSONLogicallyEqual`, match any actual value of their kind.
+const (
+	AnyPlaceholder = "<any>"
+	UUIDPlaceholder = "<uuid>"
+	StringPlaceholder = "<string>"
+	NumberPlaceholder = "<number>"
+	TimePlaceholder = "<time>"
+)
+
+var uuidRegex = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+)
+
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	ignored [][]string
+}
+
+// IgnorePaths ignores the values at `pointers`, JSON pointers such as
+// `/meta/requestId`, in both documents; a `*` segment matches any key or
+// index, e.g. `/items/*/id`. As in RFC 6901, `""` is the whole document and
+// `/` is the empty key of the root object.
+func IgnorePaths(pointers ...string) JSONOption {
+
+	return func(o *jsonOptions) {
+		for _, p := range pointers {
+			o.ignored = append(o.ignored, splitPointer(p))
+		}
+	}
+}
+
+// JSONLogicallyEqual asserts that the JSON documents `expected` and `actual`
+// are logically equal: objects are compared regardless of key order and
+// numbers by value, so that `1.0` equals `1`. String values of the expected
+// document may be placeholders, such as `"<any>"` or `"<uuid>"`.
+//
+// `expected` and `actual` may be strings, byte slices or `json.RawMessage`s;
+// any other value is marshalled to JSON first. Mismatches are reported with
+// the JSON pointer of each.
+func JSONLogicallyEqual(
+	t *testing.T,
+	expected any,
+	actual any,
+	opts ...JSONOption,
+) bool {
+
+	o := &jsonOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	e, err := decodeJSONDocument(expected)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Expected value is not valid JSON: %v", err))
+	}
+	a, err := decodeJSONDocument(actual)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Actual value is not valid JSON: %v", err))
+	}
+
+	var mismatches []string
+	compareJSON(o, nil, e, a, &mismatches)
+	if len(mismatches) == 0 {
+		return true
+	}
+
+	return assert.Fail(t, "JSON not logically equal:\n" + strings.Join(mismatches, "\n"))
+}
+
+func decodeJSONDocument(v any) (any, error) {
+
+	var b []byte
+	switch v := v.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case json.RawMessage:
+		b = v
+	default:
+		var err error
+		b, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc any
+	err := dec.Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+	return doc, nil
+}
+
+func compareJSON(o *jsonOptions, path []string, e, a any, mismatches *[]string) {
+
+	if o.isIgnored(path) {
+		return
+	}
+
+	if s, ok := e.(string); ok && matchesPlaceholder(s, a) {
+		return
+	}
+
+	mismatch := func(format string, args ...any) {
+		*mismatches = append(*mismatches, formatPointer(path) + ": " + fmt.Sprintf(format, args...))
+	}
+
+	switch e := e.(type) {
+	case map[string]any:
+		obj, ok := a.(map[string]any)
+		if !ok {
+			mismatch("expected object, actual %s", jsonString(a))
+			return
+		}
+		for _, key := range sortedKeys(e) {
+			keyPath := appendPath(path, key)
+			av, ok := obj[key]
+			if !ok {
+				if !o.isIgnored(keyPath) {
+					*mismatches = append(*mismatches, formatPointer(keyPath) + ": missing from actual")
+				}
+				continue
+			}
+			compareJSON(o, keyPath, e[key], av, mismatches)
+		}
+		for _, key := range sortedKeys(obj) {
+			keyPath := appendPath(path, key)
+			if _, ok := e[key]; !ok && !o.isIgnored(keyPath) {
+				*mismatches = append(*mismatches, formatPointer(keyPath) + ": unexpected in actual, " + jsonString(obj[key]))
+			}
+		}
+	case []any:
+		arr, ok := a.([]any)
+		if !ok {
+			mismatch("expected array, actual %s", jsonString(a))
+			return
+		}
+		if len(e) != len(arr) {
+			mismatch("expected array of length %d, actual length %d", len(e), len(arr))
+		}
+		for i := 0; i < len(e) && i < len(arr); i++ {
+			compareJSON(o, appendPath(path, fmt.Sprint(i)), e[i], arr[i], mismatches)
+		}
+	case json.Number:
+		n, ok := a.(json.Number)
+		if !ok || !numbersEqual(e, n) {
+			mismatch("expected %s, actual %s", e, jsonString(a))
+		}
+	default:
+		if e != a {
+			mismatch("expected %s, actual %s", jsonString(e), jsonString(a))
+		}
+	}
+}
+
+func matchesPlaceholder(placeholder string, a any) bool {
+
+	switch placeholder {
+	case AnyPlaceholder:
+		return true
+	case UUIDPlaceholder:
+		s, ok := a.(string)
+		return ok && uuidRegex.MatchString(s)
+	case StringPlaceholder:
+		_, ok := a.(string)
+		return ok
+	case NumberPlaceholder:
+		_, ok := a.(json.Number)
+		return ok
+	case TimePlaceholder:
+		s, ok := a.(string)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func numbersEqual(a, b json.Number) bool {
+
+	ad, err := decimal.NewFromString(a.String())
+	if err != nil {
+		return a == b
+	}
+	bd, err := decimal.NewFromString(b.String())
+	if err != nil {
+		return false
+	}
+	return ad.Equal(bd)
+}
+
+func (o *jsonOptions) isIgnored(path []string) bool {
+
+	for _, ignored := range o.ignored {
+		if len(ignored) != len(path) {
+			continue
+		}
+		match := true
+		for i := range path {
+			if ignored[i] != "*" && ignored[i] != path[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func appendPath(path []string, segment string) []string {
+	return append(append([]string(nil), path...), segment)
+}
+
+// formatPointer formats `path` as a JSON pointer, as in RFC 6901.
+func formatPointer(path []string) string {
+
+	if len(path) == 0 {
+		return "(root)"
+	}
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var b strings.Builder
+	for _, segment := range path {
+		b.WriteString("/" + escaper.Replace(segment))
+	}
+	return b.String()
+}
+
+func splitPointer(pointer string) []string {
+
+	if pointer == "" {
+		return nil
+	}
+	unescaper := strings.NewReplacer("~1", "/", "~0", "~")
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, s := range segments {
+		segments[i] = unescaper.Replace(s)
+	}
+	return segments
+}
+
+func sortedKeys(m map[string]any) []string {
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonString(v any) string {
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
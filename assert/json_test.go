@@ -0,0 +1,162 @@
+package assert_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/assert"
+)
+
+func TestJSONLogicallyEqual(t *testing.T) {
+
+	testCases := []struct{
+		name string
+		expected any
+		actual any
+		opts []assert.JSONOption
+		pass bool
+	}{
+		{
+			name: "object keys in any order",
+			expected: `{"a": 1, "b": "x"}`,
+			actual: `{"b": "x", "a": 1}`,
+			pass: true,
+		},
+		{
+			name: "numbers compared by value",
+			expected: `{"a": 1, "b": [1.50, 2e2]}`,
+			actual: []byte(`{"a": 1.0, "b": [1.5, 200]}`),
+			pass: true,
+		},
+		{
+			name: "numbers not equal",
+			expected: `{"a": 1}`,
+			actual: `{"a": 1.01}`,
+		},
+		{
+			name: "number and string not equal",
+			expected: `{"a": 1}`,
+			actual: `{"a": "1"}`,
+		},
+		{
+			name: "missing key",
+			expected: `{"a": 1, "b": 2}`,
+			actual: `{"a": 1}`,
+		},
+		{
+			name: "unexpected key",
+			expected: `{"a": 1}`,
+			actual: `{"a": 1, "b": null}`,
+		},
+		{
+			name: "array order matters",
+			expected: `[1, 2]`,
+			actual: `[2, 1]`,
+		},
+		{
+			name: "array lengths differ",
+			expected: `[1, 2]`,
+			actual: `[1, 2, 3]`,
+		},
+		{
+			name: "nested values",
+			expected: `{"a": {"b": [{"c": true}]}}`,
+			actual: `{"a": {"b": [{"c": false}]}}`,
+		},
+		{
+			name: "marshals other values",
+			expected: map[string]any{"a": []int{1, 2}},
+			actual: json.RawMessage(`{"a": [1.0, 2.0]}`),
+			pass: true,
+		},
+		{
+			name: "placeholders",
+			expected: `{
+				"id": "<uuid>",
+				"name": "<string>",
+				"total": "<number>",
+				"createdAt": "<time>",
+				"meta": "<any>"
+			}`,
+			actual: `{
+				"id": "3f1c2b8e-9a4d-4c6e-8f00-1a2b3c4d5e6f",
+				"name": "a",
+				"total": 12.5,
+				"createdAt": "2021-03-04T05:06:07.123Z",
+				"meta": {"x": [1]}
+			}`,
+			pass: true,
+		},
+		{
+			name: "uuid placeholder does not match other strings",
+			expected: `{"id": "<uuid>"}`,
+			actual: `{"id": "not-a-uuid"}`,
+		},
+		{
+			name: "any placeholder requires the key",
+			expected: `{"id": "<any>"}`,
+			actual: `{}`,
+		},
+		{
+			name: "ignored paths",
+			expected: `{"a": 1, "meta": {"requestId": "x"}, "items": [{"id": 1, "v": 2}]}`,
+			actual: `{"a": 1, "meta": {"requestId": "y", "trace": "z"}, "items": [{"id": 7, "v": 2}]}`,
+			opts: []assert.JSONOption{
+				assert.IgnorePaths("/meta/requestId", "/meta/trace", "/items/*/id"),
+			},
+			pass: true,
+		},
+		{
+			name: "escaped pointers",
+			expected: `{"a/b": 1, "c~d": 2}`,
+			actual: `{"a/b": 3, "c~d": 4}`,
+			opts: []assert.JSONOption{
+				assert.IgnorePaths("/a~1b", "/c~0d"),
+			},
+			pass: true,
+		},
+		{
+			name: "slash pointer is the empty key",
+			expected: `{"": 1, "a": 2}`,
+			actual: `{"": 3, "a": 2}`,
+			opts: []assert.JSONOption{
+				assert.IgnorePaths("/"),
+			},
+			pass: true,
+		},
+		{
+			name: "slash pointer is not the root",
+			expected: `{"": 1, "a": 2}`,
+			actual: `{"": 1, "a": 3}`,
+			opts: []assert.JSONOption{
+				assert.IgnorePaths("/"),
+			},
+		},
+		{
+			name: "empty pointer is the root",
+			expected: `{"a": 2}`,
+			actual: `[1]`,
+			opts: []assert.JSONOption{
+				assert.IgnorePaths(""),
+			},
+			pass: true,
+		},
+		{
+			name: "invalid json",
+			expected: `{"a": 1}`,
+			actual: `{"a": 1`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+
+			var fakeT testing.T
+			res := assert.JSONLogicallyEqual(&fakeT, test.expected, test.actual, test.opts...)
+			tfyassert.Equal(t, test.pass, res)
+			tfyassert.Equal(t, !test.pass, fakeT.Failed())
+		})
+	}
+}
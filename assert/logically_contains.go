@@ -0,0 +1,273 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// LogicallyContains asserts that `actual` contains `expected`, compared as by
+// `LogicallyEqual` except that:
+//
+//   - zero-valued fields of structs in `expected` are ignored,
+//   - maps in `expected` need only be a subset of those in `actual`, and
+//   - every element of a slice in `expected` must match a different element
+//     of the slice in `actual`, in any order.
+func LogicallyContains(
+	t *testing.T,
+	expected any,
+	actual any,
+	s ...any,
+) bool {
+
+	return logicallyContains(t, expected, actual, nil, nil, s...)
+}
+
+// LogicallyContainsIgnoring asserts as `LogicallyContains`, but also ignores
+// the fields at `ignored` when they are set in `expected`; fields are given
+// by dotted paths from the root value, e.g. `Address.Postcode`, and fields of
+// slice elements by the path of the slice, e.g. `Items.Qty`.
+func LogicallyContainsIgnoring(
+	t *testing.T,
+	expected any,
+	actual any,
+	ignored []string,
+	s ...any,
+) bool {
+
+	return logicallyContains(t, expected, actual, ignored, nil, s...)
+}
+
+// LogicallyContainsComparingZeroFields asserts as `LogicallyContains`, but
+// compares the fields at `fields` even when they are zero-valued in
+// `expected`; fields are given as for `LogicallyContainsIgnoring`.
+func LogicallyContainsComparingZeroFields(
+	t *testing.T,
+	expected any,
+	actual any,
+	fields []string,
+	s ...any,
+) bool {
+
+	return logicallyContains(t, expected, actual, nil, fields, s...)
+}
+
+func logicallyContains(
+	t *testing.T,
+	expected any,
+	actual any,
+	ignored []string,
+	zeroFields []string,
+	s ...any,
+) bool {
+
+	if _, ok := expected.(matcher); ok {
+		return LogicallyEqual(t, expected, actual, s...)
+	}
+
+	if expected == nil || actual == nil {
+		return assert.Equal(t, expected, actual, s...)
+	}
+
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		return assert.Equal(t, expected, actual, s...)
+	}
+
+	c := &containsComparison{
+		t: t,
+		ignored: fieldSet(ignored),
+		zeroFields: fieldSet(zeroFields),
+	}
+	c.contains(reflect.ValueOf(expected), reflect.ValueOf(actual), "", "")
+
+	if len(c.mismatches) == 0 {
+		return true
+	}
+	return assert.Fail(
+		t,
+		"Actual does not logically contain expected:\n" + strings.Join(c.mismatches, "\n"),
+		s...,
+	)
+}
+
+func fieldSet(fields []string) map[string]bool {
+
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[strings.TrimPrefix(f, ".")] = true
+	}
+	return set
+}
+
+type containsComparison struct {
+	t *testing.T
+	// ignored are the dotted paths of the fields which are never compared.
+	ignored map[string]bool
+	// zeroFields are the dotted paths of the fields compared even when zero.
+	zeroFields map[string]bool
+	mismatches []string
+}
+
+// contains compares `e` and `a`, recording mismatches at `path`, in the
+// notation of `LogicallyEqual` messages; `fieldPath` is the dotted path of
+// the struct fields leading to the values.
+func (c *containsComparison) contains(e, a reflect.Value, path, fieldPath string) bool {
+
+	if res, ok := maybeMatch(e, a); ok {
+		if res {
+			return true
+		}
+		return c.mismatch(path, "expected %s, actual %s", format(e), format(a))
+	}
+	if _, ok := maybeCallCmp(e, a); ok {
+		return c.equal(e, a, path)
+	}
+	if _, ok := maybeCallEqual(e, a); ok {
+		return c.equal(e, a, path)
+	}
+
+	switch e.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if e.IsNil() {
+			return true
+		}
+		if a.IsNil() {
+			return c.mismatch(path, "expected %s, actual nil", format(e))
+		}
+		if e.Elem().Type() != a.Elem().Type() {
+			return c.mismatch(path, "expected %s, actual %s", format(e.Elem()), format(a.Elem()))
+		}
+		return c.contains(e.Elem(), a.Elem(), path, fieldPath)
+	case reflect.Struct:
+		ok := true
+		for i := 0; i < e.NumField(); i++ {
+			name := e.Type().Field(i).Name
+			fp := name
+			if fieldPath != "" {
+				fp = fieldPath + "." + name
+			}
+			if c.ignored[fp] || (e.Field(i).IsZero() && !c.zeroFields[fp]) {
+				continue
+			}
+			ok = c.contains(e.Field(i), a.Field(i), path + "." + name, fp) && ok
+		}
+		return ok
+	case reflect.Map:
+		ok := true
+		iter := e.MapRange()
+		for iter.Next() {
+			keyPath := path + ".['" + formatKey(iter.Key()) + "']"
+			av := a.MapIndex(iter.Key())
+			if !av.IsValid() {
+				ok = c.mismatch(keyPath, "missing from actual") && ok
+				continue
+			}
+			ok = c.contains(iter.Value(), av, keyPath, fieldPath) && ok
+		}
+		return ok
+	case reflect.Slice:
+		return c.sliceContains(e, a, path, fieldPath)
+	case reflect.Array:
+		ok := true
+		for i := 0; i < e.Len(); i++ {
+			ok = c.contains(e.Index(i), a.Index(i), fmt.Sprintf("%s.[%d]", path, i), fieldPath) && ok
+		}
+		return ok
+	default:
+		return c.equal(e, a, path)
+	}
+}
+
+// sliceContains matches each element of `e` to a different element of `a`,
+// finding a maximum matching of the elements by augmenting paths so that an
+// element of `e` never takes the only element of `a` another could match.
+func (c *containsComparison) sliceContains(e, a reflect.Value, path, fieldPath string) bool {
+
+	candidates := make([][]int, e.Len())
+	for i := 0; i < e.Len(); i++ {
+		for j := 0; j < a.Len(); j++ {
+			probe := &containsComparison{t: c.t, ignored: c.ignored, zeroFields: c.zeroFields}
+			if probe.contains(e.Index(i), a.Index(j), "", fieldPath) {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	// matchedTo maps each element of `a` to the element of `e` it is matched
+	// to, or -1.
+	matchedTo := make([]int, a.Len())
+	for j := range matchedTo {
+		matchedTo[j] = -1
+	}
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for _, j := range candidates[i] {
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if matchedTo[j] == -1 || augment(matchedTo[j], visited) {
+				matchedTo[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	ok := true
+	for i := 0; i < e.Len(); i++ {
+		if !augment(i, make([]bool, a.Len())) {
+			ok = c.mismatch(
+				fmt.Sprintf("%s.[%d]", path, i),
+				"no element of actual matches %s",
+				format(e.Index(i)),
+			) && ok
+		}
+	}
+	return ok
+}
+
+func (c *containsComparison) equal(e, a reflect.Value, path string) bool {
+
+	if valuesLogicallyEqual(&failureRecorder{}, e, a) {
+		return true
+	}
+	return c.mismatch(path, "expected %s, actual %s", format(e), format(a))
+}
+
+func (c *containsComparison) mismatch(path, msg string, args ...any) bool {
+
+	if path == "" {
+		path = "."
+	}
+	c.mismatches = append(c.mismatches, path + ": " + fmt.Sprintf(msg, args...))
+	return false
+}
+
+func format(v reflect.Value) string {
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+	if !v.CanInterface() {
+		return fmt.Sprintf("%v", v)
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%#v", v.Interface())
+}
+
+func formatKey(key reflect.Value) string {
+
+	if key.CanInterface() {
+		return fmt.Sprint(key.Interface())
+	}
+	return fmt.Sprint(key)
+}
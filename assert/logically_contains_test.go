@@ -0,0 +1,243 @@
+package assert_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/assert"
+)
+
+type Address struct {
+	City string
+	Postcode string
+}
+
+type Order struct {
+	ID string
+	Paid bool
+	Total decimal.Decimal
+	Address *Address
+	Items []OrderLine
+	Tags map[string]string
+	note string
+}
+
+type OrderLine struct {
+	SKU string
+	Qty int
+}
+
+func TestLogicallyContains(t *testing.T) {
+
+	actual := Order{
+		ID: "o-1",
+		Paid: true,
+		Total: decimal.New(1250, -2),
+		Address: &Address{City: "Cape Town", Postcode: "8001"},
+		Items: []OrderLine{
+			{SKU: "a", Qty: 1},
+			{SKU: "b", Qty: 2},
+			{SKU: "c", Qty: 3},
+		},
+		Tags: map[string]string{"channel": "web", "promo": "x"},
+		note: "gift",
+	}
+
+	testCases := []struct{
+		name string
+		expected Order
+		ignored []string
+		zeroFields []string
+		pass bool
+	}{
+		{
+			name: "empty expected",
+			pass: true,
+		},
+		{
+			name: "some fields",
+			expected: Order{
+				ID: "o-1",
+				Total: decimal.New(125, -1),
+			},
+			pass: true,
+		},
+		{
+			name: "field not equal",
+			expected: Order{
+				ID: "o-2",
+			},
+		},
+		{
+			name: "nested pointer fields",
+			expected: Order{
+				Address: &Address{City: "Cape Town"},
+			},
+			pass: true,
+		},
+		{
+			name: "nested pointer fields not equal",
+			expected: Order{
+				Address: &Address{Postcode: "8000"},
+			},
+		},
+		{
+			name: "slice elements in any order",
+			expected: Order{
+				Items: []OrderLine{
+					{SKU: "c"},
+					{Qty: 1},
+				},
+			},
+			pass: true,
+		},
+		{
+			name: "slice element not contained",
+			expected: Order{
+				Items: []OrderLine{
+					{SKU: "d"},
+				},
+			},
+		},
+		{
+			name: "slice elements must match different elements",
+			expected: Order{
+				Items: []OrderLine{
+					{SKU: "a"},
+					{SKU: "a"},
+				},
+			},
+		},
+		{
+			name: "map subset",
+			expected: Order{
+				Tags: map[string]string{"channel": "web"},
+			},
+			pass: true,
+		},
+		{
+			name: "map key missing",
+			expected: Order{
+				Tags: map[string]string{"referrer": "web"},
+			},
+		},
+		{
+			name: "unexported fields",
+			expected: Order{
+				note: "gift",
+			},
+			pass: true,
+		},
+		{
+			name: "zero fields listed are compared",
+			expected: Order{
+				ID: "o-1",
+			},
+			zeroFields: []string{"Paid"},
+		},
+		{
+			name: "nested zero fields listed are compared",
+			expected: Order{
+				Address: &Address{City: "Cape Town"},
+			},
+			zeroFields: []string{"Address.Postcode"},
+		},
+		{
+			name: "zero fields listed within slices are compared",
+			expected: Order{
+				Items: []OrderLine{
+					{SKU: "b"},
+				},
+			},
+			zeroFields: []string{"Items.Qty"},
+		},
+		{
+			name: "fields listed are ignored",
+			expected: Order{
+				ID: "o-2",
+				Total: decimal.New(125, -1),
+			},
+			ignored: []string{"ID"},
+			pass: true,
+		},
+		{
+			name: "fields not listed are still compared",
+			expected: Order{
+				ID: "o-2",
+			},
+			ignored: []string{"Paid"},
+		},
+		{
+			name: "nested fields listed are ignored",
+			expected: Order{
+				Address: &Address{City: "Durban", Postcode: "8001"},
+			},
+			ignored: []string{"Address.City"},
+			pass: true,
+		},
+		{
+			name: "fields listed within slices are ignored",
+			expected: Order{
+				Items: []OrderLine{
+					{SKU: "b", Qty: 9},
+				},
+			},
+			ignored: []string{"Items.Qty"},
+			pass: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+
+			var fakeT testing.T
+			var res bool
+			switch {
+			case test.ignored != nil:
+				res = assert.LogicallyContainsIgnoring(&fakeT, test.expected, actual, test.ignored)
+			case test.zeroFields != nil:
+				res = assert.LogicallyContainsComparingZeroFields(&fakeT, test.expected, actual, test.zeroFields)
+			default:
+				res = assert.LogicallyContains(&fakeT, test.expected, actual)
+			}
+			tfyassert.Equal(t, test.pass, res)
+			tfyassert.Equal(t, !test.pass, fakeT.Failed())
+		})
+	}
+}
+
+func TestLogicallyContainsMaps(t *testing.T) {
+
+	actual := map[int]decimal.Decimal{
+		1: decimal.New(1, 0),
+		2: decimal.New(2, 0),
+	}
+
+	var fakeT testing.T
+	tfyassert.True(t, assert.LogicallyContains(&fakeT, map[int]decimal.Decimal{2: decimal.New(20, -1)}, actual))
+	tfyassert.False(t, assert.LogicallyContains(&fakeT, map[int]decimal.Decimal{3: decimal.New(3, 0)}, actual))
+	tfyassert.False(t, assert.LogicallyContains(&fakeT, map[string]int{}, actual))
+}
+
+func TestLogicallyContainsMatchesSliceElementsWithoutGreed(t *testing.T) {
+
+	actual := []OrderLine{
+		{SKU: "a", Qty: 2},
+		{SKU: "a", Qty: 3},
+	}
+
+	tfyassert.True(t, assert.LogicallyContains(t, []OrderLine{
+		{SKU: "a"},
+		{SKU: "a", Qty: 2},
+	}, actual))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.LogicallyContains(&fakeT, []OrderLine{
+		{SKU: "a"},
+		{SKU: "a", Qty: 2},
+		{SKU: "a"},
+	}, actual))
+	tfyassert.True(t, fakeT.Failed())
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 	"sort"
+	"strings"
 )
 
 func LogicallyEqual(
@@ -15,6 +16,53 @@ func LogicallyEqual(
 	s ...any,
 ) bool {
 
+	return compareLogically(t, a, b, s...)
+}
+
+// IsLogicallyEqual reports whether `expected` and `actual` are logically
+// equal, as compared by `LogicallyEqual`, without reporting to a test.
+func IsLogicallyEqual(expected, actual any) bool {
+
+	ok, _ := logicallyEqual(expected, actual)
+	return ok
+}
+
+// logicallyEqual compares `e` and `a` as `LogicallyEqual` does, returning the
+// failures it would report instead of reporting them.
+func logicallyEqual(e, a any) (bool, string) {
+
+	var rec failureRecorder
+	ok := compareLogically(&rec, e, a)
+	return ok, rec.String()
+}
+
+// failureRecorder collects the failures of a comparison in place of a test.
+type failureRecorder struct {
+	failures []string
+}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *failureRecorder) String() string {
+	return strings.Join(r.failures, "\n")
+}
+
+func compareLogically(
+	t assert.TestingT,
+	a any,
+	b any,
+	s ...any,
+) bool {
+
+	if m, ok := a.(matcher); ok {
+		if m.Match(b) {
+			return true
+		}
+		return assert.Fail(t, fmt.Sprintf("Not matched:\nexpected: %s\nactual  : %#v", m, b), s...)
+	}
+
 	if a == nil || b == nil {
 		return assert.Equal(t, a, b, s...)
 	}
@@ -39,12 +87,20 @@ func LogicallyEqual(
 }
 
 func valuesLogicallyEqual(
-	t *testing.T,
+	t assert.TestingT,
 	a reflect.Value,
 	b reflect.Value,
 	s ...any,
 ) bool {
 
+	if !a.IsValid() || !b.IsValid() {
+		return false
+	}
+
+	if res, ok := maybeMatch(a, b); ok {
+		return res
+	}
+
 	if res, ok := maybeCallCmp(a, b); ok {
 		return res==0
 	}
@@ -62,15 +118,78 @@ func valuesLogicallyEqual(
 		return mapsLogicallyEqual(t, a, b, s...)
 	case reflect.Slice:
 		return slicesLogicallyEqual(t, a, b, s...)
+	case reflect.Array:
+		return arraysLogicallyEqual(t, a, b, s...)
+	case reflect.Interface:
+		return interfacesLogicallyEqual(t, a, b, s...)
 	default:
+		return leavesEqual(a, b)
+	}
+}
+
+// leavesEqual compares values which are neither containers nor have a `Cmp`
+// or `Equal` method by kind, rather than with `Interface`, so that the values
+// of unexported fields can be compared too.
+func leavesEqual(a, b reflect.Value) bool {
+
+	if !a.IsValid() || !b.IsValid() {
 		return false
 	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	default:
+		return false
+	}
+}
+
+// matcher is implemented by the matchers of gotest/match, which can be
+// embedded in expected values.
+type matcher interface {
+	Match(v any) bool
+	String() string
+}
+
+// maybeMatch matches `b` with `a` if `a` is, or is an interface holding, a
+// matcher.
+func maybeMatch(a, b reflect.Value) (matchResult bool, isMatcher bool) {
+
+	if !a.IsValid() || !a.CanInterface() {
+		return false, false
+	}
+	m, ok := a.Interface().(matcher)
+	if !ok {
+		return false, false
+	}
+	if !b.IsValid() || !b.CanInterface() {
+		return false, true
+	}
+	return m.Match(b.Interface()), true
 }
 
 // maybeCallCmp performs a runtime reflection to see if the type `a` has the
 // method `Cmp(rhs TypeOf(b)) int` and calls it if it exists.
 func maybeCallCmp(a, b reflect.Value) (cmpResult int64, hasCmp bool) {
 
+	if !a.IsValid() || !b.IsValid() || !a.CanInterface() || !b.CanInterface() {
+		return 0, false
+	}
+
 	eq := a.MethodByName("Cmp")
 
 	if !eq.IsValid() {
@@ -96,6 +215,10 @@ func maybeCallCmp(a, b reflect.Value) (cmpResult int64, hasCmp bool) {
 // method `Equal(rhs TypeOf(b)) bool` and calls it if it exists.
 func maybeCallEqual(a, b reflect.Value) (eqResult bool, hasCmp bool) {
 
+	if !a.IsValid() || !b.IsValid() || !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+
 	eq := a.MethodByName("Equal")
 
 	if !eq.IsValid() {
@@ -118,7 +241,7 @@ func maybeCallEqual(a, b reflect.Value) (eqResult bool, hasCmp bool) {
 }
 
 func ptrsLogicallyEqual(
-	t *testing.T,
+	t assert.TestingT,
 	a reflect.Value,
 	b reflect.Value,
 	s ...any,
@@ -141,7 +264,7 @@ func ptrsLogicallyEqual(
 }
 
 func structsLogicallyEqual(
-	t *testing.T,
+	t assert.TestingT,
 	a reflect.Value,
 	b reflect.Value,
 	s ...any,
@@ -161,7 +284,7 @@ func structsLogicallyEqual(
 }
 
 func mapsLogicallyEqual(
-	t *testing.T,
+	t assert.TestingT,
 	a reflect.Value,
 	b reflect.Value,
 	s ...any,
@@ -180,12 +303,19 @@ func mapsLogicallyEqual(
 
 	retval := true
 	for _, key := range a.MapKeys() {
-		messageAndFieldName := append(s, ".['"+key.String()+"']")
+		messageAndFieldName := append(s, ".['"+formatKey(key)+"']")
+
+		bValue := b.MapIndex(key)
+		if !bValue.IsValid() {
+			assert.Fail(t, "Key of map missing from actual: "+formatKey(key), s...)
+			retval = false
+			continue
+		}
 
 		retval = retval && valuesLogicallyEqual(
 			t,
 			a.MapIndex(key),
-			b.MapIndex(key),
+			bValue,
 			messageAndFieldName...,
 		)
 	}
@@ -194,7 +324,7 @@ func mapsLogicallyEqual(
 }
 
 func slicesLogicallyEqual(
-	t *testing.T,
+	t assert.TestingT,
 	a reflect.Value,
 	b reflect.Value,
 	s ...any,
@@ -226,12 +356,52 @@ func slicesLogicallyEqual(
 	return retval
 }
 
+func arraysLogicallyEqual(
+	t assert.TestingT,
+	a reflect.Value,
+	b reflect.Value,
+	s ...any,
+) bool {
+
+	retval := true
+	for i:=0; i<a.Len(); i++ {
+		messageAndFieldName := append(s, fmt.Sprintf(".[%d]", i))
+
+		retval = retval && valuesLogicallyEqual(
+			t,
+			a.Index(i),
+			b.Index(i),
+			messageAndFieldName...,
+		)
+	}
+
+	return retval
+}
+
+func interfacesLogicallyEqual(
+	t assert.TestingT,
+	a reflect.Value,
+	b reflect.Value,
+	s ...any,
+) bool {
+
+	if a.IsNil() || b.IsNil() {
+		return a.IsNil() && b.IsNil()
+	}
+
+	if a.Elem().Type() != b.Elem().Type() {
+		return false
+	}
+
+	return valuesLogicallyEqual(t, a.Elem(), b.Elem(), s...)
+}
+
 func sortedMapKeys(value reflect.Value) []string {
 
 	mapKeys := value.MapKeys()
 	mapKeysStr := make([]string, 0, len(mapKeys))
 	for _, keyVal := range mapKeys {
-		mapKeysStr = append(mapKeysStr, keyVal.String())
+		mapKeysStr = append(mapKeysStr, formatKey(keyVal))
 	}
 	sort.Strings(mapKeysStr)
 	return mapKeysStr
@@ -345,6 +345,30 @@ func TestLogicallyEqual(t *testing.T) {
 			},
 			pass: false,
 		},
+		{
+			name: "int keyed maps when equal",
+			a: map[int]decimal.Decimal{1: decimal.NewFromFloat(2)},
+			b: map[int]decimal.Decimal{1: decimal.NewFromFloat(20).Div(decimal.NewFromFloat(10))},
+			pass: true,
+		},
+		{
+			name: "int keyed maps with different keys",
+			a: map[int]int{1: 1},
+			b: map[int]int{2: 1},
+			pass: false,
+		},
+		{
+			name: "int keyed maps with different values",
+			a: map[int]int{1: 1},
+			b: map[int]int{1: 2},
+			pass: false,
+		},
+		{
+			name: "maps with different keys which format the same",
+			a: map[any]int{1: 1},
+			b: map[any]int{"1": 1},
+			pass: false,
+		},
 		{
 			name: "map inside struct when equal",
 			a: struct{
@@ -385,6 +409,58 @@ func TestLogicallyEqual(t *testing.T) {
 			},
 			pass: false,
 		},
+		{
+			name: "decimals inside struct with other fields when equal",
+			a: struct{
+				Name string
+				Count int
+				Amount decimal.Decimal
+			}{"a", 1, decimal.New(150, -2)},
+			b: struct{
+				Name string
+				Count int
+				Amount decimal.Decimal
+			}{"a", 1, decimal.New(15, -1)},
+			pass: true,
+		},
+		{
+			name: "decimals inside struct with other fields when not equal",
+			a: struct{
+				Name string
+				Amount decimal.Decimal
+			}{"a", decimal.New(150, -2)},
+			b: struct{
+				Name string
+				Amount decimal.Decimal
+			}{"b", decimal.New(15, -1)},
+			pass: false,
+		},
+		{
+			name: "array of decimals when equal",
+			a: [2]decimal.Decimal{decimal.New(150, -2), decimal.Decimal{}},
+			b: [2]decimal.Decimal{decimal.New(15, -1), decimal.NewFromFloat(0)},
+			pass: true,
+		},
+		{
+			name: "decimals inside interface field when equal",
+			a: struct{
+				V any
+			}{decimal.New(150, -2)},
+			b: struct{
+				V any
+			}{decimal.New(15, -1)},
+			pass: true,
+		},
+		{
+			name: "different types inside interface field",
+			a: struct{
+				V any
+			}{1},
+			b: struct{
+				V any
+			}{"1"},
+			pass: false,
+		},
 		{
 			name: "slice of decimals when equal",
 			a: []decimal.Decimal{
@@ -439,6 +515,7 @@ func TestLogicallyEqual(t *testing.T) {
 			var fakeT testing.T
 			res := assert.LogicallyEqual(&fakeT, test.a, test.b, test.s...)
 			tfyassert.Equal(t, test.pass, res)
+			tfyassert.Equal(t, test.pass, assert.IsLogicallyEqual(test.a, test.b))
 		})
 	}
 }
@@ -746,6 +823,7 @@ func TestLogicallyEqualWithPtrs(t *testing.T) {
 			b := test.bCtr()
 			res := assert.LogicallyEqual(&fakeT, a, b, test.s...)
 			tfyassert.Equal(t, test.pass, res)
+			tfyassert.Equal(t, test.pass, assert.IsLogicallyEqual(a, b))
 		})
 	}
 }
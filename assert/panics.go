@@ -0,0 +1,111 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// PanicsWithLogicallyEqual asserts that `fn` panics with a value logically
+// equal to `expected`, as compared by `LogicallyEqual`.
+func PanicsWithLogicallyEqual(
+	t *testing.T,
+	expected any,
+	fn func(),
+	s ...any,
+) bool {
+
+	value, panicked, stack := recoverPanic(fn)
+	if !panicked {
+		return assert.Fail(t, fmt.Sprintf("Function did not panic, expected panic with %#v", expected), s...)
+	}
+
+	if IsLogicallyEqual(expected, value) {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf(
+		"Panic value not logically equal:\nexpected: %#v\nactual  : %#v\n\nPanic stack:\n%s",
+		expected,
+		value,
+		stack,
+	), s...)
+}
+
+// PanicsMatching asserts that `fn` panics with a value matched by `m`, such
+// as a matcher of gotest/match.
+func PanicsMatching(
+	t *testing.T,
+	m interface{ Match(v any) bool; String() string },
+	fn func(),
+	s ...any,
+) bool {
+
+	value, panicked, stack := recoverPanic(fn)
+	if !panicked {
+		return assert.Fail(t, fmt.Sprintf("Function did not panic, expected panic with %s", m), s...)
+	}
+
+	if m.Match(value) {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf(
+		"Panic value not matched:\nexpected: %s\nactual  : %#v\n\nPanic stack:\n%s",
+		m,
+		value,
+		stack,
+	), s...)
+}
+
+// PanicsWithError asserts that `fn` panics with an error for which
+// `errors.Is(err, target)`.
+func PanicsWithError(
+	t *testing.T,
+	target error,
+	fn func(),
+	s ...any,
+) bool {
+
+	value, panicked, stack := recoverPanic(fn)
+	if !panicked {
+		return assert.Fail(t, fmt.Sprintf("Function did not panic, expected panic with error %s", errorString(target)), s...)
+	}
+
+	err, ok := value.(error)
+	if !ok {
+		return assert.Fail(t, fmt.Sprintf(
+			"Panic value is not an error:\nexpected: error %s\nactual  : %#v\n\nPanic stack:\n%s",
+			errorString(target),
+			value,
+			stack,
+		), s...)
+	}
+	if errors.Is(err, target) {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf(
+		"Panic error is not %s\n%s\n\nPanic stack:\n%s",
+		errorString(target),
+		ErrorTree(err),
+		stack,
+	), s...)
+}
+
+// recoverPanic calls `fn` and returns the value it panicked with, if it did,
+// and the stack of the panicking goroutine at the point of the panic.
+func recoverPanic(fn func()) (value any, panicked bool, stack string) {
+
+	panicked = true
+	defer func() {
+		if panicked {
+			value = recover()
+			stack = string(debug.Stack())
+		}
+	}()
+
+	fn()
+	panicked = false
+	return
+}
@@ -0,0 +1,69 @@
+package assert_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/assert"
+	"github.com/thecodedproject/gotest/match"
+)
+
+type PanicValue struct {
+	Code int
+	Amount decimal.Decimal
+}
+
+func TestPanicsWithLogicallyEqual(t *testing.T) {
+
+	tfyassert.True(t, assert.PanicsWithLogicallyEqual(t, PanicValue{1, decimal.New(15, -1)}, func() {
+		panic(PanicValue{1, decimal.New(150, -2)})
+	}))
+	tfyassert.True(t, assert.PanicsWithLogicallyEqual(t, "boom", func() {
+		panic("boom")
+	}))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.PanicsWithLogicallyEqual(&fakeT, PanicValue{Code: 1}, func() {
+		panic(PanicValue{Code: 2})
+	}))
+	tfyassert.False(t, assert.PanicsWithLogicallyEqual(&fakeT, "boom", func() {}))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestPanicsMatching(t *testing.T) {
+
+	tfyassert.True(t, assert.PanicsMatching(t, match.Regex(`^index out of range`), func() {
+		panic("index out of range [3] with length 2")
+	}))
+	tfyassert.True(t, assert.PanicsMatching(t, match.Field("Code", match.Gt(400)), func() {
+		panic(&PanicValue{Code: 500})
+	}))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.PanicsMatching(&fakeT, match.NonEmpty(), func() {
+		panic("")
+	}))
+	tfyassert.False(t, assert.PanicsMatching(&fakeT, match.Any(), func() {}))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestPanicsWithError(t *testing.T) {
+
+	tfyassert.True(t, assert.PanicsWithError(t, errNotFound, func() {
+		panic(fmt.Errorf("load: %w", errNotFound))
+	}))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.PanicsWithError(&fakeT, errNotFound, func() {
+		panic(errors.New("not found"))
+	}))
+	tfyassert.False(t, assert.PanicsWithError(&fakeT, errNotFound, func() {
+		panic("not found")
+	}))
+	tfyassert.False(t, assert.PanicsWithError(&fakeT, errNotFound, func() {}))
+	tfyassert.True(t, fakeT.Failed())
+}
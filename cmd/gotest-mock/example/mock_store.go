@@ -0,0 +1,104 @@
+// Code generated by gotest-mock. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	gotestmock "github.com/thecodedproject/gotest/mock"
+	"github.com/thecodedproject/gotest/rand"
+)
+
+// MockStore is a mock of Store.
+type MockStore struct {
+	gotestmock.Mock
+}
+
+var _ Store = (*MockStore)(nil)
+
+func init() {
+	gotestmock.Register(func(t *testing.T) Store {
+		return NewMockStore(t)
+	})
+}
+
+// NewMockStore returns a mock which asserts that its expectations were met
+// when the test finishes.
+func NewMockStore(t *testing.T) *MockStore {
+	m := &MockStore{}
+	m.Init(t, true)
+	return m
+}
+
+func (m *MockStore) Accounts(ctx context.Context, prefixes ...string) []string {
+	args := m.Called(ctx, prefixes)
+	var r0 []string
+	if len(args) > 0 {
+		if v := args.Get(0); v != nil {
+			r0 = v.([]string)
+		}
+	} else if m.Generates() {
+		r0 = rand.New[[]string](m.T())
+	}
+	return r0
+}
+
+// OnAccounts sets up an expected call to Accounts; the arguments are compared
+// with `assert.LogicallyEqual` unless they are testify matchers.
+func (m *MockStore) OnAccounts(ctx any, prefixes any) *mock.Call {
+	return m.On("Accounts", gotestmock.Arg(ctx), gotestmock.Arg(prefixes))
+}
+
+func (m *MockStore) Close() {
+	m.Called()
+}
+
+// OnClose sets up an expected call to Close; the arguments are compared
+// with `assert.LogicallyEqual` unless they are testify matchers.
+func (m *MockStore) OnClose() *mock.Call {
+	return m.On("Close")
+}
+
+func (m *MockStore) Get(ctx context.Context, account string) (Balance, error) {
+	args := m.Called(ctx, account)
+	var r0 Balance
+	if len(args) > 0 {
+		if v := args.Get(0); v != nil {
+			r0 = v.(Balance)
+		}
+	} else if m.Generates() {
+		r0 = rand.New[Balance](m.T())
+	}
+	var r1 error
+	if len(args) > 1 {
+		if v := args.Get(1); v != nil {
+			r1 = v.(error)
+		}
+	}
+	return r0, r1
+}
+
+// OnGet sets up an expected call to Get; the arguments are compared
+// with `assert.LogicallyEqual` unless they are testify matchers.
+func (m *MockStore) OnGet(ctx any, account any) *mock.Call {
+	return m.On("Get", gotestmock.Arg(ctx), gotestmock.Arg(account))
+}
+
+func (m *MockStore) Put(ctx context.Context, b Balance) error {
+	args := m.Called(ctx, b)
+	var r0 error
+	if len(args) > 0 {
+		if v := args.Get(0); v != nil {
+			r0 = v.(error)
+		}
+	}
+	return r0
+}
+
+// OnPut sets up an expected call to Put; the arguments are compared
+// with `assert.LogicallyEqual` unless they are testify matchers.
+func (m *MockStore) OnPut(ctx any, b any) *mock.Call {
+	return m.On("Put", gotestmock.Arg(ctx), gotestmock.Arg(b))
+}
@@ -0,0 +1,33 @@
+// Package example declares an interface to demonstrate and test the mocks
+// generated by gotest-mock.
+package example
+
+import (
+	"context"
+	"io"
+
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/thecodedproject/gotest/cmd/gotest-mock -iface Store -out mock_store.go -rand
+
+type Balance struct {
+	Account string
+	Amount decimal.Decimal
+}
+
+type Store interface {
+	Get(ctx context.Context, account string) (Balance, error)
+	Put(ctx context.Context, b Balance) error
+	Accounts(ctx context.Context, prefixes ...string) []string
+	Close()
+}
+
+// Opener only has results of interface types, for which mocks never generate
+// values.
+type Opener interface {
+	Open(name string) (io.ReadCloser, error)
+	Close() error
+}
+
+type Nothing interface{}
@@ -0,0 +1,48 @@
+package example_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/cmd/gotest-mock/example"
+	gotest "github.com/thecodedproject/gotest/util"
+)
+
+func TestMockStore(t *testing.T) {
+
+	ctx := context.Background()
+	s := example.NewMockStore(t)
+
+	s.OnPut(mock.Anything, example.Balance{Account: "a", Amount: gotest.DS("1.50")}).
+		Return(nil)
+	s.OnGet(mock.Anything, "a").
+		Return(example.Balance{Account: "a", Amount: gotest.DS("1.5")}, nil)
+	s.OnGet(mock.Anything, "b").
+		Return(example.Balance{}, errors.New("not found"))
+	s.OnAccounts(mock.Anything, []string{"a", "b"}).
+		Return([]string{"a", "b"})
+	s.OnClose()
+
+	require.NoError(t, s.Put(ctx, example.Balance{Account: "a", Amount: gotest.DI(15, -1)}))
+	b, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "a", b.Account)
+	_, err = s.Get(ctx, "b")
+	require.EqualError(t, err, "not found")
+	require.Equal(t, []string{"a", "b"}, s.Accounts(ctx, "a", "b"))
+	s.Close()
+}
+
+func TestMockStoreRandomResults(t *testing.T) {
+
+	s := example.NewMockStore(t)
+	s.OnGet(mock.Anything, mock.Anything)
+
+	b, err := s.Get(context.Background(), "a")
+	require.NoError(t, err)
+	require.NotEmpty(t, b.Account)
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type config struct {
+	PkgDir string
+	Iface string
+	// OutPkg is the package of the generated mock; when empty, or the name of
+	// the package of the interface, the mock is generated in that package.
+	OutPkg string
+	Name string
+	Rand bool
+}
+
+func generate(c config) ([]byte, error) {
+
+	pkg, err := loadPackage(c.PkgDir)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := pkg.Scope().Lookup(c.Iface)
+	if obj == nil {
+		return nil, fmt.Errorf("no type %s in package %s", c.Iface, pkg.Name())
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", c.Iface)
+	}
+	it, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", c.Iface)
+	}
+	if named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("%s is generic, which is not supported", c.Iface)
+	}
+
+	if c.OutPkg == "" {
+		c.OutPkg = pkg.Name()
+	}
+	if c.Name == "" {
+		c.Name = "Mock" + c.Iface
+	}
+
+	g := &generator{
+		config: c,
+		imports: make(map[string]string),
+		aliases: make(map[string]bool),
+		used: make(map[string]bool),
+	}
+	g.self = pkg
+	if c.OutPkg != pkg.Name() {
+		g.external = true
+		g.addImport(pkg.Path(), pkg.Name())
+	}
+
+	return g.generate(it)
+}
+
+var (
+	fset = token.NewFileSet()
+	// srcImporter is shared by every package loaded, so that dependencies are
+	// only type checked once.
+	srcImporter = importer.ForCompiler(fset, "source", nil)
+)
+
+// loadPackage parses and type checks the non-test Go files in `dir`,
+// importing its dependencies from source.
+func loadPackage(dir string) (*types.Package, error) {
+
+	path, err := importPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	var name string
+	for n, p := range pkgs {
+		if name != "" {
+			return nil, fmt.Errorf("found packages %s and %s in %s", name, n, dir)
+		}
+		name = n
+		for _, f := range p.Files {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{
+		Importer: srcImporter,
+	}
+	return conf.Check(path, fset, files, nil)
+}
+
+func importPath(dir string) (string, error) {
+
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot find import path of %s: %v", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+type generator struct {
+	config
+
+	self *types.Package
+	// external is true when the mock is generated outside the package of the
+	// interface.
+	external bool
+	// imports maps import paths to their aliases.
+	imports map[string]string
+	aliases map[string]bool
+	// used holds the import paths used by the generated code; only these are
+	// imported.
+	used map[string]bool
+}
+
+func (g *generator) generate(it *types.Interface) ([]byte, error) {
+
+	// These imports are added first, so that they keep their names, but are
+	// only imported if they are used
+	g.addImport("testing", "testing")
+	g.addImport("github.com/stretchr/testify/mock", "mock")
+	g.addImport("github.com/thecodedproject/gotest/mock", "gotestmock")
+	g.addImport("github.com/thecodedproject/gotest/rand", "rand")
+
+	var body bytes.Buffer
+	g.writeType(&body)
+	for i := 0; i < it.NumMethods(); i++ {
+		g.writeMethod(&body, it.Method(i))
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by gotest-mock. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", g.OutPkg)
+	g.writeImports(&src)
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated mock: %v", err)
+	}
+	return formatted, nil
+}
+
+func (g *generator) writeType(w *bytes.Buffer) {
+
+	g.use("testing")
+	g.use("github.com/thecodedproject/gotest/mock")
+
+	fmt.Fprintf(w, "// %s is a mock of %s.\n", g.Name, g.typeName())
+	fmt.Fprintf(w, "type %s struct {\n\tgotestmock.Mock\n}\n\n", g.Name)
+
+	fmt.Fprintf(w, "var _ %s = (*%s)(nil)\n\n", g.typeName(), g.Name)
+
+	fmt.Fprintf(w, "func init() {\n")
+	fmt.Fprintf(w, "\tgotestmock.Register(func(t *testing.T) %s {\n", g.typeName())
+	fmt.Fprintf(w, "\t\treturn New%s(t)\n\t})\n}\n\n", g.Name)
+
+	fmt.Fprintf(w, "// New%s returns a mock which asserts that its expectations were met\n", g.Name)
+	fmt.Fprintf(w, "// when the test finishes.\n")
+	fmt.Fprintf(w, "func New%s(t *testing.T) *%s {\n", g.Name, g.Name)
+	fmt.Fprintf(w, "\tm := &%s{}\n", g.Name)
+	fmt.Fprintf(w, "\tm.Init(t, %t)\n", g.Rand)
+	fmt.Fprintf(w, "\treturn m\n}\n\n")
+}
+
+func (g *generator) writeMethod(w *bytes.Buffer, fn *types.Func) {
+
+	sig := fn.Type().(*types.Signature)
+
+	// Qualify the types first, so that the parameter names do not clash with
+	// the imports they add.
+	paramTypes := make([]string, sig.Params().Len())
+	for i := range paramTypes {
+		typ := sig.Params().At(i).Type()
+		if sig.Variadic() && i == len(paramTypes) - 1 {
+			paramTypes[i] = "..." + g.qualify(typ.(*types.Slice).Elem())
+		} else {
+			paramTypes[i] = g.qualify(typ)
+		}
+	}
+	var results []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, g.qualify(sig.Results().At(i).Type()))
+	}
+
+	params := g.paramNames(sig.Params())
+	var decl []string
+	for i, p := range params {
+		decl = append(decl, p + " " + paramTypes[i])
+	}
+	resultDecl := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultDecl = "(" + resultDecl + ")"
+	}
+
+	fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", g.Name, fn.Name(), strings.Join(decl, ", "), resultDecl)
+	if len(results) == 0 {
+		fmt.Fprintf(w, "\tm.Called(%s)\n}\n\n", strings.Join(params, ", "))
+	} else {
+		fmt.Fprintf(w, "\targs := m.Called(%s)\n", strings.Join(params, ", "))
+		var rets []string
+		for i, res := range results {
+			ret := fmt.Sprintf("r%d", i)
+			rets = append(rets, ret)
+			fmt.Fprintf(w, "\tvar %s %s\n", ret, res)
+			fmt.Fprintf(w, "\tif len(args) > %d {\n", i)
+			fmt.Fprintf(w, "\t\tif v := args.Get(%d); v != nil {\n\t\t\t%s = v.(%s)\n\t\t}\n", i, ret, res)
+			_, isIface := sig.Results().At(i).Type().Underlying().(*types.Interface)
+			if !isIface {
+				g.use("github.com/thecodedproject/gotest/rand")
+				fmt.Fprintf(w, "\t} else if m.Generates() {\n\t\t%s = rand.New[%s](m.T())\n", ret, res)
+			}
+			fmt.Fprintf(w, "\t}\n")
+		}
+		fmt.Fprintf(w, "\treturn %s\n}\n\n", strings.Join(rets, ", "))
+	}
+
+	var matchers []string
+	for _, p := range params {
+		matchers = append(matchers, "gotestmock.Arg(" + p + ")")
+	}
+	onArgs := strconv.Quote(fn.Name())
+	if len(matchers) > 0 {
+		onArgs += ", " + strings.Join(matchers, ", ")
+	}
+	fmt.Fprintf(w, "// On%s sets up an expected call to %s; the arguments are compared\n", fn.Name(), fn.Name())
+	fmt.Fprintf(w, "// with `assert.LogicallyEqual` unless they are testify matchers.\n")
+	var anyDecl []string
+	for _, p := range params {
+		anyDecl = append(anyDecl, p + " any")
+	}
+	g.use("github.com/stretchr/testify/mock")
+	fmt.Fprintf(w, "func (m *%s) On%s(%s) *mock.Call {\n", g.Name, fn.Name(), strings.Join(anyDecl, ", "))
+	fmt.Fprintf(w, "\treturn m.On(%s)\n}\n\n", onArgs)
+}
+
+// paramNames returns the names of `params`, replacing blank, missing and
+// clashing names.
+func (g *generator) paramNames(params *types.Tuple) []string {
+
+	names := make([]string, params.Len())
+	used := make(map[string]bool)
+	for i := range names {
+		n := params.At(i).Name()
+		if n == "" || n == "_" || n == "m" || n == "args" || n == "v" ||
+			strings.HasPrefix(n, "r") && len(n) > 1 && strings.Trim(n[1:], "0123456789") == "" ||
+			g.aliases[n] || used[n] {
+
+			n = fmt.Sprintf("a%d", i)
+		}
+		used[n] = true
+		names[i] = n
+	}
+	return names
+}
+
+func (g *generator) typeName() string {
+
+	if g.external {
+		return g.use(g.self.Path()) + "." + g.Iface
+	}
+	return g.Iface
+}
+
+func (g *generator) qualify(t types.Type) string {
+
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.self && !g.external {
+			return ""
+		}
+		g.addImport(p.Path(), p.Name())
+		return g.use(p.Path())
+	})
+}
+
+// use marks the import of `path`, which must have been added, as used and
+// returns its alias.
+func (g *generator) use(path string) string {
+
+	g.used[path] = true
+	return g.imports[path]
+}
+
+// addImport adds an import of `path`, with an alias based on `name` which
+// does not clash with any other import, and returns the alias.
+func (g *generator) addImport(path, name string) string {
+
+	if alias, ok := g.imports[path]; ok {
+		return alias
+	}
+	alias := name
+	for i := 2; g.aliases[alias]; i++ {
+		alias = fmt.Sprintf("%s%d", name, i)
+	}
+	g.imports[path] = alias
+	g.aliases[alias] = true
+	return alias
+}
+
+func (g *generator) writeImports(w *bytes.Buffer) {
+
+	paths := make([]string, 0, len(g.used))
+	for path := range g.used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Standard library imports come first, as goimports groups them
+	sort.SliceStable(paths, func(i, j int) bool {
+		return isStd(paths[i]) && !isStd(paths[j])
+	})
+
+	fmt.Fprintf(w, "import (\n")
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			fmt.Fprintf(w, "\n")
+		}
+		alias := g.imports[path]
+		if alias == filepath.Base(path) {
+			fmt.Fprintf(w, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(w, "\t%s %q\n", alias, path)
+		}
+	}
+	fmt.Fprintf(w, ")\n\n")
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
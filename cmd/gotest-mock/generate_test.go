@@ -0,0 +1,95 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/snapshot"
+)
+
+func TestGenerateIsUpToDate(t *testing.T) {
+
+	src, err := generate(config{
+		PkgDir: "example",
+		Iface: "Store",
+		Rand: true,
+	})
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile("example/mock_store.go")
+	require.NoError(t, err)
+	require.Equal(t, string(committed), string(src), "run go generate ./cmd/gotest-mock/example")
+}
+
+func TestGenerate(t *testing.T) {
+
+	t.Run("other package", func(t *testing.T) {
+		src, err := generate(config{
+			PkgDir: "example",
+			Iface: "Store",
+			OutPkg: "mocks",
+			Name: "Store",
+		})
+		require.NoError(t, err)
+		requireCompiles(t, src)
+		snapshot.Match(t, string(src))
+	})
+
+	t.Run("only interface results", func(t *testing.T) {
+		src, err := generate(config{
+			PkgDir: "example",
+			Iface: "Opener",
+			OutPkg: "mocks",
+			Rand: true,
+		})
+		require.NoError(t, err)
+		requireCompiles(t, src)
+		require.NotContains(t, string(src), "gotest/rand")
+	})
+
+	t.Run("no methods", func(t *testing.T) {
+		src, err := generate(config{
+			PkgDir: "example",
+			Iface: "Nothing",
+			OutPkg: "mocks",
+		})
+		require.NoError(t, err)
+		requireCompiles(t, src)
+		require.NotContains(t, string(src), "testify/mock")
+	})
+
+	t.Run("not an interface", func(t *testing.T) {
+		_, err := generate(config{
+			PkgDir: "example",
+			Iface: "Balance",
+		})
+		require.EqualError(t, err, "Balance is not an interface")
+	})
+
+	t.Run("unknown interface", func(t *testing.T) {
+		_, err := generate(config{
+			PkgDir: "example",
+			Iface: "Unknown",
+		})
+		require.EqualError(t, err, "no type Unknown in package example")
+	})
+}
+
+// requireCompiles type checks the mock `src`, generated outside the package of
+// its interface.
+func requireCompiles(t *testing.T, src []byte) {
+
+	f, err := parser.ParseFile(fset, "mock.go", src, 0)
+	require.NoError(t, err)
+
+	conf := types.Config{
+		Importer: srcImporter,
+	}
+	_, err = conf.Check("mocks", fset, []*ast.File{f}, nil)
+	require.NoError(t, err, string(src))
+}
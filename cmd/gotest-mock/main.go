@@ -0,0 +1,57 @@
+// Command gotest-mock generates a testify mock of an interface, wired to the
+// gotest helpers; expected arguments are matched with `assert.LogicallyEqual`
+// and, with `-rand`, the results of calls set up without any are generated by
+// `rand.New`. Generated mocks register themselves for use by `mock.Auto`.
+//
+// Usage:
+//
+//	//go:generate go run github.com/thecodedproject/gotest/cmd/gotest-mock -iface Store -out mock_store.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+var (
+	pkgDir = flag.String("pkg", ".", "directory of the package declaring the interface")
+	iface = flag.String("iface", "", "name of the interface to mock")
+	out = flag.String("out", "", "file to write the mock to; defaults to stdout")
+	outPkg = flag.String("outpkg", "", "package of the generated mock; defaults to the package of the interface")
+	name = flag.String("name", "", "name of the mock type; defaults to Mock<iface>")
+	useRand = flag.Bool("rand", false, "return values generated by rand.New from calls set up without results")
+)
+
+func main() {
+
+	flag.Parse()
+
+	if *iface == "" {
+		fmt.Fprintln(os.Stderr, "gotest-mock: -iface is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(config{
+		PkgDir: *pkgDir,
+		Iface: *iface,
+		OutPkg: *outPkg,
+		Name: *name,
+		Rand: *useRand,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gotest-mock:", err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	err = os.WriteFile(*out, src, 0o644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gotest-mock:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,71 @@
+package concurrency
+
+import (
+	"sync"
+	gotime "time"
+)
+
+// Barrier blocks goroutines calling `Await` until a given number of them
+// are waiting, then releases them all together; it can be reused.
+type Barrier struct {
+	mu sync.Mutex
+	parties int
+	waiting int
+	release chan struct{}
+}
+
+// NewBarrier returns a barrier for `parties` goroutines.
+func NewBarrier(parties int) *Barrier {
+
+	return &Barrier{
+		parties: parties,
+		release: make(chan struct{}),
+	}
+}
+
+// Await waits up to `timeout` for all the parties of the barrier to be
+// waiting and reports whether they were; a goroutine which times out no
+// longer counts as waiting.
+func (b *Barrier) Await(timeout gotime.Duration) bool {
+
+	b.mu.Lock()
+	b.waiting++
+	if b.waiting >= b.parties {
+		close(b.release)
+		b.release = make(chan struct{})
+		b.waiting = 0
+		b.mu.Unlock()
+		return true
+	}
+	release := b.release
+	b.mu.Unlock()
+
+	select {
+	case <-release:
+		return true
+	case <-gotime.After(timeout):
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	select {
+	case <-release:
+		// Released while timing out
+		return true
+	default:
+		b.waiting--
+		return false
+	}
+}
+
+// AssertAwait asserts that all the parties of the barrier are waiting
+// within `timeout`.
+func (b *Barrier) AssertAwait(t TestingT, timeout gotime.Duration) bool {
+
+	if b.Await(timeout) {
+		return true
+	}
+	t.Errorf("gotest/concurrency: barrier for %d parties not released within %v", b.parties, timeout)
+	return false
+}
@@ -0,0 +1,71 @@
+package concurrency_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/concurrency"
+)
+
+func TestBarrierReleasesAllPartiesTogether(t *testing.T) {
+
+	b := concurrency.NewBarrier(3)
+
+	var mu sync.Mutex
+	var arrived, passed []int
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func(i int) {
+			defer wg.Done()
+			mu.Lock()
+			arrived = append(arrived, i)
+			mu.Unlock()
+
+			if b.AssertAwait(t, time.Second) {
+				mu.Lock()
+				// Every party must have arrived before any passes
+				tfyassert.Len(t, arrived, 3)
+				passed = append(passed, i)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	tfyassert.ElementsMatch(t, []int{0, 1, 2}, passed)
+}
+
+func TestBarrierCanBeReused(t *testing.T) {
+
+	b := concurrency.NewBarrier(2)
+
+	done := make(chan bool)
+	go func() {
+		done <- b.Await(time.Second) && b.Await(time.Second)
+	}()
+
+	tfyassert.True(t, b.Await(time.Second))
+	tfyassert.True(t, b.Await(time.Second))
+	tfyassert.True(t, <-done)
+}
+
+func TestBarrierAwaitTimesOutAndWithdraws(t *testing.T) {
+
+	b := concurrency.NewBarrier(2)
+
+	var fakeT testing.T
+	tfyassert.False(t, b.AssertAwait(&fakeT, 10*time.Millisecond))
+	tfyassert.True(t, fakeT.Failed())
+
+	// The party which timed out no longer counts as waiting
+	done := make(chan bool)
+	go func() {
+		done <- b.Await(time.Second)
+	}()
+	tfyassert.True(t, b.Await(time.Second))
+	tfyassert.True(t, <-done)
+}
@@ -0,0 +1,85 @@
+package concurrency
+
+import (
+	"sync"
+	gotime "time"
+)
+
+// TestingT is satisfied by `*testing.T` and by the `*T` of the goroutines
+// started by `RunConcurrently`.
+type TestingT interface {
+	Errorf(format string, args ...any)
+}
+
+// Latch is released once it has been counted down a given number of times;
+// it cannot be reset.
+type Latch struct {
+	mu sync.Mutex
+	count int
+	done chan struct{}
+}
+
+// NewLatch returns a latch released after `count` calls to `CountDown`, or
+// an already released latch if `count` is not positive.
+func NewLatch(count int) *Latch {
+
+	l := &Latch{
+		count: count,
+		done: make(chan struct{}),
+	}
+	if count <= 0 {
+		close(l.done)
+	}
+	return l
+}
+
+// CountDown counts down the latch, releasing it when the count reaches zero.
+func (l *Latch) CountDown() {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.count <= 0 {
+		return
+	}
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns the number of calls to `CountDown` left before the latch is
+// released.
+func (l *Latch) Count() int {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Done returns a channel which is closed when the latch is released.
+func (l *Latch) Done() <-chan struct{} {
+	return l.done
+}
+
+// Wait waits up to `timeout` for the latch to be released and reports
+// whether it was.
+func (l *Latch) Wait(timeout gotime.Duration) bool {
+
+	select {
+	case <-l.done:
+		return true
+	case <-gotime.After(timeout):
+		return false
+	}
+}
+
+// AssertReleased asserts that the latch is released within `timeout`.
+func (l *Latch) AssertReleased(t TestingT, timeout gotime.Duration) bool {
+
+	if l.Wait(timeout) {
+		return true
+	}
+	t.Errorf("gotest/concurrency: latch not released within %v; count is %d", timeout, l.Count())
+	return false
+}
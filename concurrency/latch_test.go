@@ -0,0 +1,49 @@
+package concurrency_test
+
+import (
+	"testing"
+	"time"
+
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/concurrency"
+)
+
+func TestLatchReleasedAfterCountDowns(t *testing.T) {
+
+	l := concurrency.NewLatch(3)
+
+	for i := 0; i < 3; i++ {
+		tfyassert.False(t, l.Wait(time.Millisecond))
+		tfyassert.Equal(t, 3-i, l.Count())
+		go l.CountDown()
+		<-time.After(5*time.Millisecond)
+	}
+
+	tfyassert.True(t, l.AssertReleased(t, time.Second))
+	tfyassert.Equal(t, 0, l.Count())
+
+	l.CountDown()
+	tfyassert.Equal(t, 0, l.Count())
+}
+
+func TestLatchWithNoCountIsReleased(t *testing.T) {
+
+	l := concurrency.NewLatch(0)
+
+	select {
+	case <-l.Done():
+	default:
+		t.Error("latch not released")
+	}
+}
+
+func TestLatchAssertReleasedFailsOnTimeout(t *testing.T) {
+
+	l := concurrency.NewLatch(2)
+	l.CountDown()
+
+	var fakeT testing.T
+	tfyassert.False(t, l.AssertReleased(&fakeT, 10*time.Millisecond))
+	tfyassert.True(t, fakeT.Failed())
+}
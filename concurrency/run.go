@@ -0,0 +1,144 @@
+package concurrency
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// T is the test of one of the goroutines started by `RunConcurrently`. It
+// implements the `TestingT` interfaces of testify's assert and require
+// packages; `FailNow` exits only its goroutine.
+type T struct {
+	name string
+	index int
+
+	mu sync.Mutex
+	failed bool
+	logs []string
+	panicked bool
+}
+
+// Index returns the index of the goroutine, from 0 to n-1.
+func (t *T) Index() int {
+	return t.index
+}
+
+func (t *T) Name() string {
+	return t.name
+}
+
+func (t *T) Helper() {
+}
+
+func (t *T) Logf(format string, args ...any) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *T) Errorf(format string, args ...any) {
+
+	t.Logf(format, args...)
+	t.Fail()
+}
+
+func (t *T) Fatalf(format string, args ...any) {
+
+	t.Logf(format, args...)
+	t.FailNow()
+}
+
+func (t *T) Fail() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+}
+
+func (t *T) FailNow() {
+
+	t.Fail()
+	runtime.Goexit()
+}
+
+func (t *T) Failed() bool {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failed
+}
+
+// RunConcurrently runs `fn` in `n` goroutines, started together once they
+// are all running, and waits for them to finish. The failures of each
+// goroutine, including panics with their stacks, are reported to `t` under
+// the name of the goroutine; it returns whether none failed.
+//
+// When `n` is 0 `fn` is not run; a negative `n` fails the test immediately.
+func RunConcurrently(t *testing.T, n int, fn func(t *T)) bool {
+
+	t.Helper()
+
+	if n < 0 {
+		require.Fail(t, fmt.Sprintf("gotest/concurrency: cannot run %d goroutines", n))
+	}
+
+	ts := make([]*T, n)
+	ready := NewLatch(n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(n)
+
+	for i := 0; i < n; i++ {
+		ts[i] = &T{
+			name: fmt.Sprintf("%s/goroutine_%d", t.Name(), i),
+			index: i,
+		}
+		go func(gt *T) {
+
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					gt.mu.Lock()
+					gt.panicked = true
+					gt.mu.Unlock()
+					gt.Errorf("panic: %v\n\n%s", r, debug.Stack())
+				}
+			}()
+
+			ready.CountDown()
+			<-start
+			fn(gt)
+		}(ts[i])
+	}
+
+	<-ready.Done()
+	close(start)
+	wg.Wait()
+
+	ok := true
+	for _, gt := range ts {
+		if !gt.Failed() {
+			continue
+		}
+		ok = false
+		what := "failed"
+		if gt.panicked {
+			what = "panicked"
+		}
+		t.Errorf(
+			"gotest/concurrency: goroutine %d of %d %s:\n%s",
+			gt.index,
+			n,
+			what,
+			strings.Join(gt.logs, "\n"),
+		)
+	}
+	return ok
+}
@@ -0,0 +1,106 @@
+package concurrency_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tfyassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/concurrency"
+	"github.com/thecodedproject/gotest/leak"
+)
+
+func TestRunConcurrentlyStartsAllGoroutinesTogether(t *testing.T) {
+
+	leak.Check(t)
+
+	b := concurrency.NewBarrier(8)
+	var seen [8]int32
+
+	ok := concurrency.RunConcurrently(t, 8, func(t *concurrency.T) {
+		atomic.AddInt32(&seen[t.Index()], 1)
+		// Only passes if all 8 goroutines run at the same time
+		b.AssertAwait(t, time.Second)
+	})
+
+	tfyassert.True(t, ok)
+	for i, n := range seen {
+		tfyassert.Equal(t, int32(1), n, "goroutine %d", i)
+	}
+}
+
+func TestRunConcurrentlyReportsFailingGoroutines(t *testing.T) {
+
+	var finished int32
+
+	var fakeT testing.T
+	ok := concurrency.RunConcurrently(&fakeT, 4, func(t *concurrency.T) {
+		tfyassert.NotEqual(t, 1, t.Index())
+		require.NotEqual(t, 2, t.Index())
+		atomic.AddInt32(&finished, 1)
+	})
+
+	tfyassert.False(t, ok)
+	tfyassert.True(t, fakeT.Failed())
+	// Goroutine 2 stopped at the require
+	tfyassert.Equal(t, int32(3), finished)
+}
+
+func TestRunConcurrentlyRecoversPanics(t *testing.T) {
+
+	var fakeT testing.T
+	ok := concurrency.RunConcurrently(&fakeT, 3, func(t *concurrency.T) {
+		if t.Index() == 0 {
+			panic("boom")
+		}
+	})
+
+	tfyassert.False(t, ok)
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestTRecordsFailures(t *testing.T) {
+
+	var failures []bool
+	concurrency.RunConcurrently(&testing.T{}, 1, func(t *concurrency.T) {
+		failures = append(failures, t.Failed())
+		t.Logf("not a failure")
+		failures = append(failures, t.Failed())
+		t.Errorf("a failure")
+		failures = append(failures, t.Failed())
+		t.FailNow()
+		failures = append(failures, t.Failed())
+	})
+
+	tfyassert.Equal(t, []bool{false, false, true}, failures)
+}
+
+func TestRunConcurrentlyWithNoGoroutines(t *testing.T) {
+
+	called := false
+	ok := concurrency.RunConcurrently(t, 0, func(t *concurrency.T) {
+		called = true
+	})
+
+	tfyassert.True(t, ok)
+	tfyassert.False(t, called)
+}
+
+func TestRunConcurrentlyFailsForNegativeN(t *testing.T) {
+
+	var fakeT testing.T
+	called := false
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		concurrency.RunConcurrently(&fakeT, -1, func(t *concurrency.T) {
+			called = true
+		})
+	}()
+	<-done
+
+	tfyassert.True(t, fakeT.Failed())
+	tfyassert.False(t, called)
+}
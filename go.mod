@@ -3,13 +3,13 @@ module github.com/thecodedproject/gotest
 go 1.20
 
 require (
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.5.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.1.0 // indirect
 	gopkg.in/yaml.v2 v2.2.8 // indirect
 )
@@ -0,0 +1,188 @@
+package httpfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+var record = flag.Bool(
+	"httpfake.record",
+	false,
+	"record the cassettes of gotest/httpfake from real requests instead of replaying them",
+)
+
+// Interaction is a request and the response to it, as stored in a cassette.
+type Interaction struct {
+	Request RecordedRequest `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL string `json:"url"`
+	Body string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status int `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body string `json:"body,omitempty"`
+}
+
+// Cassette returns a transport which replays the interactions stored in the
+// cassette `testdata/cassettes/<name>.json`. When the tests are run with
+// `-httpfake.record` it instead sends the requests with
+// `http.DefaultTransport` and writes them and their responses to the
+// cassette when the test finishes.
+//
+// Requests are replayed from the first unused interaction with the same
+// method, URL and body.
+func Cassette(t *testing.T, name string) http.RoundTripper {
+
+	c := &cassette{
+		t: t,
+		path: filepath.Join("testdata", "cassettes", filepath.FromSlash(name) + ".json"),
+		recording: *record,
+	}
+
+	if c.recording {
+		t.Cleanup(c.save)
+		return c
+	}
+
+	b, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf(
+			"gotest/httpfake: cassette %s does not exist; run the test with -httpfake.record to record it",
+			c.path,
+		)
+	} else if err != nil {
+		t.Fatalf("gotest/httpfake: cannot read cassette: %v", err)
+	}
+	err = json.Unmarshal(b, &c.interactions)
+	if err != nil {
+		t.Fatalf("gotest/httpfake: cannot decode cassette %s: %v", c.path, err)
+	}
+	c.used = make([]bool, len(c.interactions))
+	return c
+}
+
+type cassette struct {
+	t *testing.T
+	path string
+	recording bool
+
+	mu sync.Mutex
+	interactions []Interaction
+	used []bool
+}
+
+func (c *cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL: req.URL.String(),
+		Body: string(body),
+	}
+
+	if c.recording {
+		return c.recordRoundTrip(req, recorded)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, in := range c.interactions {
+		if c.used[i] || in.Request != recorded {
+			continue
+		}
+		c.used[i] = true
+		return &http.Response{
+			Status: fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode: in.Response.Status,
+			Proto: "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header: in.Response.Header.Clone(),
+			Body: io.NopCloser(bytes.NewReader([]byte(in.Response.Body))),
+			ContentLength: int64(len(in.Response.Body)),
+			Request: req,
+		}, nil
+	}
+
+	c.t.Errorf("gotest/httpfake: no interaction in cassette %s for %s %s", c.path, req.Method, req.URL)
+	return nil, fmt.Errorf("gotest/httpfake: no recorded interaction for %s %s", req.Method, req.URL)
+}
+
+func (c *cassette) recordRoundTrip(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+
+	// A round tripper must not modify the request, so the body, which has
+	// been read, is sent from a clone
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = io.NopCloser(bytes.NewReader([]byte(recorded.Body)))
+	}
+
+	resp, err := http.DefaultTransport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Date")
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: header,
+			Body: string(body),
+		},
+	})
+	c.mu.Unlock()
+	return resp, nil
+}
+
+func (c *cassette) save() {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(c.path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(c.path, append(b, '\n'), 0o644)
+	}
+	if err != nil {
+		c.t.Errorf("gotest/httpfake: cannot write cassette: %v", err)
+	}
+}
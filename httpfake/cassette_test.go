@@ -0,0 +1,93 @@
+package httpfake_test
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/httpfake"
+)
+
+func TestCassetteReplay(t *testing.T) {
+
+	client := &http.Client{
+		Transport: httpfake.Cassette(t, "rates"),
+	}
+
+	resp, err := client.Get("https://api.example.com/rates?base=USD")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.Equal(t, `{"EUR":"0.92"}`, string(body))
+
+	resp, err = client.Post(
+		"https://api.example.com/rates",
+		"application/json",
+		bytes.NewBufferString(`{"EUR":"0.93"}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestCassetteReplayFailsForUnrecordedRequests(t *testing.T) {
+
+	var fakeT testing.T
+	client := &http.Client{
+		Transport: httpfake.Cassette(&fakeT, "rates"),
+	}
+
+	_, err := client.Get("https://api.example.com/rates?base=GBP")
+	require.Error(t, err)
+	require.True(t, fakeT.Failed())
+}
+
+func TestCassetteRecord(t *testing.T) {
+
+	s := httpfake.NewServer(t)
+	s.Expect("GET", "/ping").Respond(http.StatusOK, "pong")
+	s.Expect("POST", "/rates").WithJSONBody(map[string]string{"EUR": "0.93"}).Respond(http.StatusNoContent, nil)
+
+	t.Cleanup(func() {
+		os.Remove("testdata/cassettes/record_test.json")
+	})
+
+	t.Run("record", func(t *testing.T) {
+		require.NoError(t, flag.Set("httpfake.record", "true"))
+		defer flag.Set("httpfake.record", "false")
+
+		client := &http.Client{
+			Transport: httpfake.Cassette(t, "record_test"),
+		}
+		resp, err := client.Get(s.URL + "/ping")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		// The request is not modified, beyond its body being consumed
+		req, err := http.NewRequest("POST", s.URL + "/rates", bytes.NewBufferString(`{"EUR":"0.93"}`))
+		require.NoError(t, err)
+		reqBody := req.Body
+		resp, err = client.Transport.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.True(t, req.Body == reqBody)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		client := &http.Client{
+			Transport: httpfake.Cassette(t, "record_test"),
+		}
+		resp, err := client.Get(s.URL + "/ping")
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "pong", string(body))
+	})
+}
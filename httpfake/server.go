@@ -0,0 +1,320 @@
+package httpfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	gotime "time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/thecodedproject/gotest/assert"
+	"github.com/thecodedproject/gotest/time"
+)
+
+// Server is an `httptest.Server` which responds to the requests it was set
+// up to expect, and asserts that they were all made when the test finishes.
+type Server struct {
+	*httptest.Server
+
+	t *testing.T
+
+	mu sync.Mutex
+	routes []*Route
+}
+
+// Route is an expected request and the response to it.
+type Route struct {
+	method string
+	path string
+	query url.Values
+	header http.Header
+	body any
+	hasBody bool
+	times int
+
+	status int
+	respHeader http.Header
+	respBody []byte
+	latency gotime.Duration
+
+	calls int
+}
+
+// NewServer starts a server, which is closed when the test finishes.
+func NewServer(t *testing.T) *Server {
+
+	s := &Server{
+		t: t,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(func() {
+		s.Close()
+		s.verify()
+	})
+	return s
+}
+
+// Expect sets up an expected request with `method` to `path`, which is
+// responded to with `200 OK` and an empty body unless `Respond` is called.
+func (s *Server) Expect(method, path string) *Route {
+
+	r := &Route{
+		method: method,
+		path: path,
+		query: make(url.Values),
+		header: make(http.Header),
+		status: http.StatusOK,
+		respHeader: make(http.Header),
+	}
+
+	s.mu.Lock()
+	s.routes = append(s.routes, r)
+	s.mu.Unlock()
+	return r
+}
+
+// WithQuery requires the query parameter `key` of the request to be `value`.
+func (r *Route) WithQuery(key, value string) *Route {
+
+	r.query.Add(key, value)
+	return r
+}
+
+// WithHeader requires the header `key` of the request to be `value`.
+func (r *Route) WithHeader(key, value string) *Route {
+
+	r.header.Add(key, value)
+	return r
+}
+
+// WithJSONBody requires the request body to be JSON logically equal to `v`,
+// as compared by `assert.LogicallyEqual` with numbers compared as decimals.
+func (r *Route) WithJSONBody(v any) *Route {
+
+	r.body = v
+	r.hasBody = true
+	return r
+}
+
+// Times sets the number of times the request is expected; by default it is
+// expected at least once.
+func (r *Route) Times(n int) *Route {
+
+	r.times = n
+	return r
+}
+
+// Respond sets the response to the request; `body` is written as is if it
+// is a string or byte slice and as JSON otherwise.
+func (r *Route) Respond(status int, body any) *Route {
+
+	r.status = status
+	switch b := body.(type) {
+	case nil:
+		r.respBody = nil
+	case string:
+		r.respBody = []byte(b)
+	case []byte:
+		r.respBody = b
+	default:
+		j, err := json.Marshal(b)
+		if err != nil {
+			panic(fmt.Sprintf("gotest/httpfake: cannot marshal response body: %v", err))
+		}
+		r.respBody = j
+		r.respHeader.Set("Content-Type", "application/json")
+	}
+	return r
+}
+
+// RespondHeader sets the header `key` of the response to `value`.
+func (r *Route) RespondHeader(key, value string) *Route {
+
+	r.respHeader.Set(key, value)
+	return r
+}
+
+// Latency delays the response by `d` with `time.Sleep` of gotest/time, so
+// that with `time.SetFakeClockForTesting` the delay is only seen by the
+// fake clock.
+func (r *Route) Latency(d gotime.Duration) *Route {
+
+	r.latency = d
+	return r
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.t.Errorf("gotest/httpfake: cannot read body of %s %s: %v", req.Method, req.URL, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	r := s.match(req, body)
+	if r == nil {
+		s.t.Errorf("gotest/httpfake: unexpected request %s %s\n%s", req.Method, req.URL, body)
+		http.Error(w, "gotest/httpfake: unexpected request", http.StatusNotImplemented)
+		return
+	}
+
+	time.Sleep(r.latency)
+	for key, values := range r.respHeader {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(r.status)
+	w.Write(r.respBody)
+}
+
+// match returns the first route matching `req` which has not been called as
+// many times as it is expected, counting the call.
+func (s *Server) match(req *http.Request, body []byte) *Route {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.routes {
+		if r.times > 0 && r.calls >= r.times {
+			continue
+		}
+		if r.matches(req, body) {
+			r.calls++
+			return r
+		}
+	}
+	return nil
+}
+
+func (r *Route) matches(req *http.Request, body []byte) bool {
+
+	if req.Method != r.method || req.URL.Path != r.path {
+		return false
+	}
+
+	query := req.URL.Query()
+	for key, values := range r.query {
+		if !equalStrings(query[key], values) {
+			return false
+		}
+	}
+	for key, values := range r.header {
+		if !equalStrings(req.Header.Values(key), values) {
+			return false
+		}
+	}
+
+	if r.hasBody {
+		return jsonLogicallyEqual(r.body, body)
+	}
+	return true
+}
+
+func (s *Server) verify() {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.routes {
+		if r.times > 0 && r.calls != r.times {
+			s.t.Errorf("gotest/httpfake: %s called %d time(s), expected %d", r, r.calls, r.times)
+		} else if r.times == 0 && r.calls == 0 {
+			s.t.Errorf("gotest/httpfake: %s never called", r)
+		}
+	}
+}
+
+func (r *Route) String() string {
+
+	var b strings.Builder
+	b.WriteString(r.method + " " + r.path)
+	if len(r.query) > 0 {
+		b.WriteString("?" + r.query.Encode())
+	}
+	return b.String()
+}
+
+func equalStrings(a, b []string) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonLogicallyEqual reports whether `body` is JSON logically equal to
+// `expected`, once both are decoded with numbers as decimals.
+func jsonLogicallyEqual(expected any, body []byte) bool {
+
+	var expectedJSON []byte
+	switch e := expected.(type) {
+	case string:
+		expectedJSON = []byte(e)
+	case []byte:
+		expectedJSON = e
+	default:
+		var err error
+		expectedJSON, err = json.Marshal(e)
+		if err != nil {
+			return false
+		}
+	}
+
+	want, err := decodeJSON(expectedJSON)
+	if err != nil {
+		return false
+	}
+	got, err := decodeJSON(body)
+	if err != nil {
+		return false
+	}
+
+	return assert.IsLogicallyEqual(want, got)
+}
+
+func decodeJSON(b []byte) (any, error) {
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	return withDecimals(v), nil
+}
+
+// withDecimals replaces the `json.Number`s in `v` with decimals.
+func withDecimals(v any) any {
+
+	switch v := v.(type) {
+	case json.Number:
+		d, err := decimal.NewFromString(v.String())
+		if err != nil {
+			return v
+		}
+		return d
+	case map[string]any:
+		for key, value := range v {
+			v[key] = withDecimals(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = withDecimals(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,116 @@
+package httpfake_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/httpfake"
+	"github.com/thecodedproject/gotest/time"
+)
+
+type Transfer struct {
+	From string `json:"from"`
+	Amount float64 `json:"amount"`
+}
+
+func get(t *testing.T, url string) (int, string) {
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestServer(t *testing.T) {
+
+	s := httpfake.NewServer(t)
+	s.Expect("GET", "/accounts/a").
+		WithQuery("fields", "balance").
+		Respond(http.StatusOK, map[string]string{"balance": "1.50"})
+	s.Expect("POST", "/transfers").
+		WithHeader("Idempotency-Key", "k1").
+		WithJSONBody(Transfer{From: "a", Amount: 1.5}).
+		Respond(http.StatusCreated, "created").
+		RespondHeader("Location", "/transfers/1").
+		Times(2)
+
+	status, body := get(t, s.URL + "/accounts/a?fields=balance")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"balance": "1.50"}`, body)
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(
+			"POST",
+			s.URL + "/transfers",
+			bytes.NewBufferString(`{"amount": 1.50, "from": "a"}`),
+		)
+		require.NoError(t, err)
+		req.Header.Set("Idempotency-Key", "k1")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, "/transfers/1", resp.Header.Get("Location"))
+	}
+}
+
+func TestServerFails(t *testing.T) {
+
+	t.Run("unexpected request", func(t *testing.T) {
+		var fakeT testing.T
+		s := httpfake.NewServer(&fakeT)
+		defer s.Close()
+		s.Expect("GET", "/a").WithQuery("x", "1")
+
+		status, _ := get(t, s.URL + "/a?x=2")
+		require.Equal(t, http.StatusNotImplemented, status)
+		require.True(t, fakeT.Failed())
+	})
+
+	t.Run("body does not match", func(t *testing.T) {
+		var fakeT testing.T
+		s := httpfake.NewServer(&fakeT)
+		defer s.Close()
+		s.Expect("POST", "/a").WithJSONBody(`{"amount": 2}`)
+
+		resp, err := http.Post(s.URL + "/a", "application/json", bytes.NewBufferString(`{"amount": 2.01}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+		require.True(t, fakeT.Failed())
+	})
+
+	t.Run("called more times than expected", func(t *testing.T) {
+		var fakeT testing.T
+		s := httpfake.NewServer(&fakeT)
+		defer s.Close()
+		s.Expect("GET", "/a").Times(1)
+
+		status, _ := get(t, s.URL + "/a")
+		require.Equal(t, http.StatusOK, status)
+		require.False(t, fakeT.Failed())
+		status, _ = get(t, s.URL + "/a")
+		require.Equal(t, http.StatusNotImplemented, status)
+		require.True(t, fakeT.Failed())
+	})
+}
+
+func TestServerLatency(t *testing.T) {
+
+	start := time.SetFakeClockForTesting(t)
+
+	s := httpfake.NewServer(t)
+	s.Expect("GET", "/slow").Latency(5 * gotime.Second)
+
+	before := gotime.Now()
+	get(t, s.URL + "/slow")
+	require.Less(t, int64(gotime.Since(before)), int64(gotime.Second))
+	require.Equal(t, start.Add(5 * gotime.Second), time.Now())
+}
@@ -0,0 +1,272 @@
+package leak
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	gotime "time"
+)
+
+const (
+	defaultTimeout = 500 * gotime.Millisecond
+	pollInterval = 10 * gotime.Millisecond
+)
+
+// defaultIgnored are functions of the goroutines which the standard library
+// and the testing package start in the background.
+var defaultIgnored = []string{
+	"testing.tRunner",
+	"testing.(*T).Run",
+	"testing.runTests",
+	"testing.(*M).startAlarm",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"runtime.ensureSigM",
+	"runtime/trace.Start.func1",
+}
+
+type Option func(*options)
+
+type options struct {
+	timeout gotime.Duration
+	ignoreTop []string
+	ignoreAny []string
+}
+
+// Timeout sets how long to wait for new goroutines to exit before reporting
+// them as leaked; it defaults to 500ms.
+func Timeout(d gotime.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// IgnoreTopFunction ignores goroutines which are currently in the function
+// `fn`, e.g. `net/http.(*persistConn).readLoop`.
+func IgnoreTopFunction(fn string) Option {
+	return func(o *options) {
+		o.ignoreTop = append(o.ignoreTop, fn)
+	}
+}
+
+// IgnoreAnyFunction ignores goroutines with the function `fn` anywhere in
+// their stack, including as the function which created them.
+func IgnoreAnyFunction(fn string) Option {
+	return func(o *options) {
+		o.ignoreAny = append(o.ignoreAny, fn)
+	}
+}
+
+var (
+	activeMu sync.Mutex
+	active = make(map[*Checker]bool)
+)
+
+// Checker finds the goroutines started after it was created, from the
+// goroutine which created it, which have not exited.
+type Checker struct {
+	opts options
+	root int64
+	// before holds the IDs of the goroutines running when the checker was
+	// created.
+	before map[int64]bool
+
+	mu sync.Mutex
+	// overlapped is set when another checker was active at the same time,
+	// so that goroutines whose ancestry is lost cannot be attributed.
+	overlapped bool
+	// unknownParents is set when the goroutine stacks do not record which
+	// goroutine started each goroutine, as before Go 1.21.
+	unknownParents bool
+}
+
+// Check fails the test when it finishes if goroutines started by it, in its
+// goroutine or in goroutines started from it, are still running after a
+// short wait; their stacks are listed.
+//
+// Every goroutine which was not running when the check started is reported,
+// except those started by the runtime or the testing package and those
+// started from goroutines which were already running, such as other tests.
+//
+// Check works with `t.Parallel()`: goroutines are attributed to the test
+// through the goroutines which started them, so goroutines started by other
+// tests are not reported. A goroutine whose ancestor on the path to the test
+// has exited can only be attributed when no other test is being checked at
+// the same time, and is otherwise ignored. Goroutine stacks only record the
+// goroutine which started each goroutine from Go 1.21, so on earlier versions
+// checks which overlap, such as in parallel tests, fail as they cannot
+// attribute goroutines.
+func Check(t *testing.T, opts ...Option) {
+
+	c := New(opts...)
+	t.Cleanup(func() {
+		leaked := c.Leaked()
+		if c.unattributable() {
+			t.Errorf(
+				"gotest/leak: cannot attribute goroutines to the test while other tests are checked, as the stacks of %s do not record which goroutine started each goroutine; Go 1.21 or later is required",
+				runtime.Version(),
+			)
+		}
+		if len(leaked) == 0 {
+			return
+		}
+
+		stacks := make([]string, len(leaked))
+		for i, g := range leaked {
+			stacks[i] = g.Stack
+		}
+		t.Errorf(
+			"gotest/leak: %d goroutine(s) leaked:\n\n%s",
+			len(leaked),
+			strings.Join(stacks, "\n\n"),
+		)
+	})
+}
+
+// New snapshots the running goroutines; it must be called in the goroutine
+// whose goroutines are to be checked, such as the test goroutine.
+func New(opts ...Option) *Checker {
+
+	c := &Checker{
+		opts: options{
+			timeout: defaultTimeout,
+		},
+		root: currentGoroutineID(),
+		before: make(map[int64]bool),
+	}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	for _, g := range allGoroutines() {
+		c.before[g.ID] = true
+	}
+
+	activeMu.Lock()
+	for other := range active {
+		other.setOverlapped()
+		c.overlapped = true
+	}
+	active[c] = true
+	activeMu.Unlock()
+
+	return c
+}
+
+// Leaked waits for the goroutines started since `c` was created to exit and
+// returns those still running after the timeout; it ends the check.
+func (c *Checker) Leaked() []Goroutine {
+
+	defer func() {
+		activeMu.Lock()
+		delete(active, c)
+		activeMu.Unlock()
+	}()
+
+	deadline := gotime.Now().Add(c.opts.timeout)
+	for {
+		leaked := c.find()
+		if len(leaked) == 0 || gotime.Now().After(deadline) {
+			return leaked
+		}
+		gotime.Sleep(pollInterval)
+	}
+}
+
+func (c *Checker) find() []Goroutine {
+
+	gs := allGoroutines()
+	byID := make(map[int64]Goroutine, len(gs))
+	unknownParents := true
+	for _, g := range gs {
+		byID[g.ID] = g
+		if g.ParentID != 0 {
+			unknownParents = false
+		}
+	}
+	c.mu.Lock()
+	c.unknownParents = unknownParents
+	c.mu.Unlock()
+
+	self := currentGoroutineID()
+	var leaked []Goroutine
+	for _, g := range gs {
+		if c.before[g.ID] || g.ID == self || g.ID == c.root || g.internal() || c.ignored(g) {
+			continue
+		}
+		if c.attributed(g, byID) {
+			leaked = append(leaked, g)
+		}
+	}
+	return leaked
+}
+
+// attributed reports whether `g` was started by the checked goroutine,
+// following the goroutines which started it.
+func (c *Checker) attributed(g Goroutine, byID map[int64]Goroutine) bool {
+
+	for {
+		if g.ParentID == c.root {
+			return true
+		}
+		if c.before[g.ParentID] {
+			return false
+		}
+		parent, ok := byID[g.ParentID]
+		if !ok {
+			// The parent was started during the check and has exited, so
+			// which goroutine started it is lost
+			return !c.isOverlapped()
+		}
+		g = parent
+	}
+}
+
+func (c *Checker) ignored(g Goroutine) bool {
+
+	for _, fn := range defaultIgnored {
+		if g.hasFunction(fn) {
+			return true
+		}
+	}
+	for _, fn := range c.opts.ignoreTop {
+		if g.TopFunction == fn {
+			return true
+		}
+	}
+	for _, fn := range c.opts.ignoreAny {
+		if g.hasFunction(fn) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Checker) setOverlapped() {
+
+	c.mu.Lock()
+	c.overlapped = true
+	c.mu.Unlock()
+}
+
+// unattributable reports whether goroutines cannot be attributed, as their
+// parents are not recorded and another checker was active.
+func (c *Checker) unattributable() bool {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.unknownParents && c.overlapped
+}
+
+func (c *Checker) isOverlapped() bool {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.overlapped
+}
+
+func (g Goroutine) String() string {
+	return fmt.Sprintf("goroutine %d [%s] in %s", g.ID, g.State, g.TopFunction)
+}
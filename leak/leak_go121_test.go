@@ -0,0 +1,27 @@
+//go:build go1.21
+
+package leak_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/leak"
+)
+
+// Goroutine stacks record which goroutine started each goroutine from Go
+// 1.21, which checks overlapping with other checks depend on.
+func TestLeakedRecordsParents(t *testing.T) {
+
+	c := leak.New(leak.Timeout(50 * time.Millisecond))
+
+	done := make(chan struct{})
+	defer close(done)
+	go blockOn(done)
+
+	leaked := c.Leaked()
+	require.Len(t, leaked, 1)
+	require.NotZero(t, leaked[0].ParentID)
+}
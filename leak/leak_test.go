@@ -0,0 +1,147 @@
+package leak_test
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/assert"
+	"github.com/thecodedproject/gotest/leak"
+)
+
+func blockOn(ch chan struct{}) {
+	<-ch
+}
+
+func TestCheck(t *testing.T) {
+
+	leak.Check(t)
+
+	done := make(chan struct{})
+	go blockOn(done)
+	time.AfterFunc(20 * time.Millisecond, func() {
+		close(done)
+	})
+}
+
+func TestCheckWithChannelAssertions(t *testing.T) {
+
+	leak.Check(t)
+
+	ch := make(chan interface{}, 1)
+	wait := assert.ChannelReceivesOnce(t, ch, "a")
+	ch <- "a"
+	require.True(t, wait())
+}
+
+func TestLeaked(t *testing.T) {
+
+	c := leak.New(leak.Timeout(50 * time.Millisecond))
+
+	done := make(chan struct{})
+	defer close(done)
+	go blockOn(done)
+
+	leaked := c.Leaked()
+	require.Len(t, leaked, 1)
+	require.Equal(t, "chan receive", leaked[0].State)
+	require.Equal(t, "github.com/thecodedproject/gotest/leak_test.blockOn", leaked[0].TopFunction)
+	require.Equal(t, "github.com/thecodedproject/gotest/leak_test.TestLeaked", leaked[0].CreatedBy)
+	require.True(t, strings.Contains(leaked[0].Stack, "leak_test.go"))
+}
+
+func TestLeakedThroughExitedGoroutines(t *testing.T) {
+
+	c := leak.New(leak.Timeout(50 * time.Millisecond))
+
+	done := make(chan struct{})
+	defer close(done)
+	var started sync.WaitGroup
+	started.Add(1)
+	go func() {
+		go blockOn(done)
+		started.Done()
+	}()
+	started.Wait()
+
+	require.Len(t, c.Leaked(), 1)
+}
+
+func TestLeakedIgnores(t *testing.T) {
+
+	done := make(chan struct{})
+	defer close(done)
+
+	c := leak.New(
+		leak.Timeout(50 * time.Millisecond),
+		leak.IgnoreTopFunction("github.com/thecodedproject/gotest/leak_test.blockOn"),
+	)
+	go blockOn(done)
+	require.Empty(t, c.Leaked())
+
+	c = leak.New(
+		leak.Timeout(50 * time.Millisecond),
+		leak.IgnoreAnyFunction("github.com/thecodedproject/gotest/leak_test.TestLeakedIgnores"),
+	)
+	go blockOn(done)
+	require.Empty(t, c.Leaked())
+}
+
+func TestLeakedInParallel(t *testing.T) {
+
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+	})
+
+	t.Run("leaks", func(t *testing.T) {
+		t.Parallel()
+
+		c := leak.New(leak.Timeout(100 * time.Millisecond))
+		go blockOn(done)
+		require.Len(t, c.Leaked(), 1)
+	})
+
+	t.Run("does not leak", func(t *testing.T) {
+		t.Parallel()
+
+		c := leak.New(leak.Timeout(100 * time.Millisecond))
+		time.Sleep(10 * time.Millisecond)
+		require.Empty(t, c.Leaked())
+	})
+}
+
+func TestLeakedThroughExitedGoroutinesOnEveryP(t *testing.T) {
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// Goroutine IDs are handed out in batches per P, so goroutines started
+	// on one P may have lower IDs than goroutines already started on another
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	startFromEveryP := func(n int, fn func()) {
+		var started sync.WaitGroup
+		started.Add(n)
+		for i := 0; i < n; i++ {
+			i := i
+			go func() {
+				time.Sleep(time.Duration(i%4) * time.Millisecond)
+				go fn()
+				started.Done()
+			}()
+		}
+		started.Wait()
+	}
+
+	before := make(chan struct{})
+	defer close(before)
+	startFromEveryP(32, func() { blockOn(before) })
+
+	c := leak.New(leak.Timeout(100 * time.Millisecond))
+	startFromEveryP(32, func() { blockOn(done) })
+	require.Len(t, c.Leaked(), 32)
+}
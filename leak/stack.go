@@ -0,0 +1,147 @@
+package leak
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Goroutine is a goroutine as listed by `runtime.Stack`.
+type Goroutine struct {
+	ID int64
+	// State is the state of the goroutine, e.g. `chan receive`.
+	State string
+	// TopFunction is the function the goroutine is currently in.
+	TopFunction string
+	// CreatedBy is the function which started the goroutine.
+	CreatedBy string
+	// ParentID is the ID of the goroutine which started the goroutine, or 0
+	// if it is not known, as always before Go 1.21.
+	ParentID int64
+	// Stack is the full trace of the goroutine.
+	Stack string
+}
+
+// hasFunction reports whether `fn` is one of the functions in the stack of
+// `g`, including the function which created it.
+func (g Goroutine) hasFunction(fn string) bool {
+
+	for _, line := range strings.Split(g.Stack, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if created, ok := strings.CutPrefix(line, "created by "); ok {
+			line, _, _ = strings.Cut(created, " in goroutine ")
+		}
+		if funcName(line) == fn {
+			return true
+		}
+	}
+	return false
+}
+
+// internal reports whether `g` was started by the runtime or the testing
+// package rather than by the code under test.
+func (g Goroutine) internal() bool {
+
+	pkg := funcPackage(g.CreatedBy)
+	return pkg == "runtime" || strings.HasPrefix(pkg, "runtime/") || pkg == "testing"
+}
+
+// funcPackage returns the import path of the package of the function `fn`,
+// e.g. `net/http` for `net/http.(*conn).serve`.
+func funcPackage(fn string) string {
+
+	slash := strings.LastIndex(fn, "/") + 1
+	if i := strings.Index(fn[slash:], "."); i >= 0 {
+		return fn[:slash+i]
+	}
+	return fn
+}
+
+func allGoroutines() []Goroutine {
+
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return parseGoroutines(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+func currentGoroutineID() int64 {
+
+	buf := make([]byte, 64)
+	n := runtime.Stack(buf, false)
+	id, _, _ := parseHeader(string(bytes.SplitN(buf[:n], []byte("\n"), 2)[0]))
+	return id
+}
+
+func parseGoroutines(dump []byte) []Goroutine {
+
+	var gs []Goroutine
+	for _, block := range strings.Split(strings.TrimSpace(string(dump)), "\n\n") {
+		lines := strings.Split(block, "\n")
+		id, state, ok := parseHeader(lines[0])
+		if !ok {
+			continue
+		}
+
+		g := Goroutine{
+			ID: id,
+			State: state,
+			Stack: block,
+		}
+		if len(lines) > 1 {
+			g.TopFunction = funcName(lines[1])
+		}
+		for _, line := range lines[1:] {
+			if !strings.HasPrefix(line, "created by ") {
+				continue
+			}
+			created := strings.TrimPrefix(line, "created by ")
+			if i := strings.Index(created, " in goroutine "); i >= 0 {
+				g.ParentID, _ = strconv.ParseInt(created[i+len(" in goroutine "):], 10, 64)
+				created = created[:i]
+			}
+			g.CreatedBy = created
+		}
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// parseHeader parses a line such as `goroutine 7 [chan receive, 2 minutes]:`.
+func parseHeader(line string) (id int64, state string, ok bool) {
+
+	rest, found := strings.CutPrefix(line, "goroutine ")
+	if !found {
+		return 0, "", false
+	}
+	idStr, rest, found := strings.Cut(rest, " ")
+	if !found {
+		return 0, "", false
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	state = strings.TrimSuffix(strings.TrimPrefix(rest, "["), "]:")
+	if i := strings.Index(state, ","); i >= 0 {
+		state = state[:i]
+	}
+	return id, state, true
+}
+
+// funcName returns the name of the function in a stack trace line such as
+// `main.(*T).run(0xc000010000, ...)`.
+func funcName(line string) string {
+
+	if i := strings.LastIndex(line, "("); i > 0 && strings.HasSuffix(line, ")") {
+		return line[:i]
+	}
+	return line
+}
@@ -0,0 +1,108 @@
+package match
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Gt matches values greater than `bound`. Numbers of any kind are compared
+// by value, and strings, times and types with a `Cmp` method, such as
+// decimals, with values of their own type.
+func Gt(bound any) Matcher {
+	return ordered("> ", bound, func(c int) bool { return c > 0 })
+}
+
+// Ge matches values greater than or equal to `bound`.
+func Ge(bound any) Matcher {
+	return ordered(">= ", bound, func(c int) bool { return c >= 0 })
+}
+
+// Lt matches values less than `bound`.
+func Lt(bound any) Matcher {
+	return ordered("< ", bound, func(c int) bool { return c < 0 })
+}
+
+// Le matches values less than or equal to `bound`.
+func Le(bound any) Matcher {
+	return ordered("<= ", bound, func(c int) bool { return c <= 0 })
+}
+
+func ordered(op string, bound any, ok func(int) bool) Matcher {
+
+	return New(fmt.Sprintf("%s%v", op, bound), func(v any) bool {
+		c, comparable := compare(v, bound)
+		return comparable && ok(c)
+	})
+}
+
+// compare compares numbers of any kind with each other by value, strings,
+// times, and values of the same type with a `Cmp` method, such as decimals.
+func compare(a, b any) (int, bool) {
+
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return at.Compare(bt), true
+	}
+
+	if ad, ok := toDecimal(a); ok {
+		bd, ok := toDecimal(b)
+		if !ok {
+			return 0, false
+		}
+		return ad.Cmp(bd), true
+	}
+
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() {
+		return 0, false
+	}
+
+	if av.Kind() == reflect.String && bv.Kind() == reflect.String {
+		switch {
+		case av.String() < bv.String():
+			return -1, true
+		case av.String() > bv.String():
+			return 1, true
+		}
+		return 0, true
+	}
+
+	cmp := av.MethodByName("Cmp")
+	if cmp.IsValid() && cmp.Type().NumIn() == 1 && cmp.Type().NumOut() == 1 &&
+		cmp.Type().In(0) == bv.Type() && cmp.Type().Out(0).Kind() == reflect.Int {
+
+		return int(cmp.Call([]reflect.Value{bv})[0].Int()), true
+	}
+	return 0, false
+}
+
+func toDecimal(v any) (decimal.Decimal, bool) {
+
+	if d, ok := v.(decimal.Decimal); ok {
+		return d, true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decimal.RequireFromString(fmt.Sprint(rv.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		// NaN and the infinities have no decimal value, so are not comparable
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return decimal.Decimal{}, false
+		}
+		return decimal.NewFromFloat(f), true
+	}
+	return decimal.Decimal{}, false
+}
@@ -0,0 +1,340 @@
+// Package match provides matchers, which can be embedded in the expected
+// values passed to `assert.LogicallyEqual` and `assert.LogicallyContains`
+// wherever the type of the expected value allows, such as in `any` fields or
+// as the expected value itself, and used as testify mock arguments with `Arg`.
+//
+// Fields of concrete types cannot hold matchers, so partial expectations of
+// structs are made with `Fields` as the expected value instead, e.g.
+//
+//	assert.LogicallyEqual(t, match.Fields(map[string]any{
+//		"ID": match.NonEmpty(),
+//		"Kind": "created",
+//	}), event)
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	tfymock "github.com/stretchr/testify/mock"
+
+	"github.com/thecodedproject/gotest/assert"
+)
+
+// Matcher matches values; `String` describes the values it matches.
+type Matcher interface {
+	Match(v any) bool
+	String() string
+}
+
+type matcher struct {
+	desc string
+	match func(v any) bool
+}
+
+func (m matcher) Match(v any) bool {
+	return m.match(v)
+}
+
+func (m matcher) String() string {
+	return m.desc
+}
+
+// New returns a matcher described by `desc` which matches the values for
+// which `match` returns true.
+func New(desc string, match func(v any) bool) Matcher {
+	return matcher{desc: desc, match: match}
+}
+
+// Arg returns a testify mock argument matching the arguments matched by `m`.
+func Arg(m Matcher) any {
+
+	return tfymock.MatchedBy(func(v any) bool {
+		return m.Match(v)
+	})
+}
+
+// Of returns `v` if it is a matcher, and otherwise `Eq(v)`.
+func Of(v any) Matcher {
+
+	if m, ok := v.(Matcher); ok {
+		return m
+	}
+	return Eq(v)
+}
+
+// Eq matches values logically equal to `expected`, as compared by
+// `assert.LogicallyEqual`.
+func Eq(expected any) Matcher {
+
+	return New(fmt.Sprintf("%#v", expected), func(v any) bool {
+		return assert.IsLogicallyEqual(expected, v)
+	})
+}
+
+// DecimalEq matches decimals equal to the decimal represented by `s`.
+func DecimalEq(s string) Matcher {
+
+	expected := decimal.RequireFromString(s)
+	return New("decimal " + s, func(v any) bool {
+		d, ok := v.(decimal.Decimal)
+		return ok && d.Equal(expected)
+	})
+}
+
+// Any matches any value, including nil.
+func Any() Matcher {
+	return New("any value", func(any) bool { return true })
+}
+
+// Nil matches nil and nil pointers, slices, maps, funcs, chans and
+// interfaces.
+func Nil() Matcher {
+	return New("nil", isNil)
+}
+
+// NonZero matches values which are not the zero value of their type.
+func NonZero() Matcher {
+
+	return New("non-zero value", func(v any) bool {
+		return v != nil && !reflect.ValueOf(v).IsZero()
+	})
+}
+
+// NonEmpty matches strings, slices, arrays, maps and chans with a length
+// greater than zero.
+func NonEmpty() Matcher {
+
+	return New("non-empty value", func(v any) bool {
+		n, ok := length(v)
+		return ok && n > 0
+	})
+}
+
+// Not matches the values not matched by `m`.
+func Not(m any) Matcher {
+
+	inner := Of(m)
+	return New("not " + inner.String(), func(v any) bool {
+		return !inner.Match(v)
+	})
+}
+
+// AnyOf matches the values matched by at least one of `ms`, each a matcher
+// or a value matched with `Eq`.
+func AnyOf(ms ...any) Matcher {
+
+	matchers := of(ms)
+	return New("any of " + describe(matchers), func(v any) bool {
+		for _, m := range matchers {
+			if m.Match(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// AllOf matches the values matched by every one of `ms`, each a matcher or
+// a value matched with `Eq`.
+func AllOf(ms ...any) Matcher {
+
+	matchers := of(ms)
+	return New("all of " + describe(matchers), func(v any) bool {
+		for _, m := range matchers {
+			if !m.Match(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Regex matches strings, byte slices and `fmt.Stringer`s matching
+// `pattern`.
+func Regex(pattern string) Matcher {
+
+	re := regexp.MustCompile(pattern)
+	return New("matching /" + pattern + "/", func(v any) bool {
+		switch v := v.(type) {
+		case string:
+			return re.MatchString(v)
+		case []byte:
+			return re.Match(v)
+		case fmt.Stringer:
+			return re.MatchString(v.String())
+		}
+		rv := reflect.ValueOf(v)
+		return rv.Kind() == reflect.String && re.MatchString(rv.String())
+	})
+}
+
+// Len matches strings, slices, arrays, maps and chans whose length is
+// matched by `n`, an int or a matcher, e.g. `Len(Gt(2))`.
+func Len(n any) Matcher {
+
+	m := Of(n)
+	return New("length " + m.String(), func(v any) bool {
+		l, ok := length(v)
+		return ok && m.Match(l)
+	})
+}
+
+// Field matches structs, or pointers to structs, whose field `name` is
+// matched by `m`, a matcher or a value matched with `Eq`.
+func Field(name string, m any) Matcher {
+
+	inner := Of(m)
+	return New(fmt.Sprintf("field %s %s", name, inner), func(v any) bool {
+		rv, ok := structValue(v)
+		if !ok {
+			return false
+		}
+		f := rv.FieldByName(name)
+		return f.IsValid() && f.CanInterface() && inner.Match(f.Interface())
+	})
+}
+
+// Fields matches structs, or pointers to structs, whose fields named by the
+// keys of `fields` are matched by their values, each a matcher or a value
+// matched with `Eq`; other fields are ignored.
+func Fields(fields map[string]any) Matcher {
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matchers := make([]Matcher, len(names))
+	descs := make([]string, len(names))
+	for i, name := range names {
+		matchers[i] = Field(name, fields[name])
+		descs[i] = name + ": " + Of(fields[name]).String()
+	}
+	return New("fields {" + strings.Join(descs, ", ") + "}", func(v any) bool {
+		if _, ok := structValue(v); !ok {
+			return false
+		}
+		for _, m := range matchers {
+			if !m.Match(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Each matches slices, arrays and maps whose every element is matched by
+// `m`, a matcher or a value matched with `Eq`.
+func Each(m any) Matcher {
+
+	inner := Of(m)
+	return New("each element " + inner.String(), func(v any) bool {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				if !inner.Match(rv.Index(i).Interface()) {
+					return false
+				}
+			}
+			return true
+		case reflect.Map:
+			iter := rv.MapRange()
+			for iter.Next() {
+				if !inner.Match(iter.Value().Interface()) {
+					return false
+				}
+			}
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// Contains matches slices and arrays with at least one element matched by
+// `m`, a matcher or a value matched with `Eq`, and strings containing `m`
+// when it is a string.
+func Contains(m any) Matcher {
+
+	inner := Of(m)
+	return New("containing " + inner.String(), func(v any) bool {
+		if s, ok := v.(string); ok {
+			sub, isString := m.(string)
+			return isString && strings.Contains(s, sub)
+		}
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				if inner.Match(rv.Index(i).Interface()) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+func of(ms []any) []Matcher {
+
+	matchers := make([]Matcher, len(ms))
+	for i, m := range ms {
+		matchers[i] = Of(m)
+	}
+	return matchers
+}
+
+func describe(ms []Matcher) string {
+
+	descs := make([]string, len(ms))
+	for i, m := range ms {
+		descs[i] = m.String()
+	}
+	return "(" + strings.Join(descs, ", ") + ")"
+}
+
+func isNil(v any) bool {
+
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// structValue returns the struct `v`, or the struct `v` points to.
+func structValue(v any) (reflect.Value, bool) {
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+func length(v any) (int, bool) {
+
+	if v == nil {
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,177 @@
+package match_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+	tfymock "github.com/stretchr/testify/mock"
+
+	"github.com/thecodedproject/gotest/assert"
+	"github.com/thecodedproject/gotest/match"
+	gotest "github.com/thecodedproject/gotest/util"
+)
+
+type Product struct {
+	Name string
+	Price decimal.Decimal
+	Tags []string
+}
+
+type Event struct {
+	ID string
+	Seq int
+	Kind string
+	At time.Time
+	Product *Product
+}
+
+func TestMatchers(t *testing.T) {
+
+	p := Product{
+		Name: "tea",
+		Price: gotest.DS("1.50"),
+		Tags: []string{"drink", "hot"},
+	}
+
+	testCases := []struct{
+		name string
+		matcher match.Matcher
+		value any
+		match bool
+	}{
+		{name: "eq", matcher: match.Eq(gotest.DS("1.5")), value: gotest.DS("1.50"), match: true},
+		{name: "eq not equal", matcher: match.Eq("a"), value: "b"},
+		{name: "decimal eq", matcher: match.DecimalEq("1.5"), value: gotest.DS("1.500"), match: true},
+		{name: "decimal eq other type", matcher: match.DecimalEq("1.5"), value: 1.5},
+		{name: "any nil", matcher: match.Any(), value: nil, match: true},
+		{name: "nil", matcher: match.Nil(), value: (*Product)(nil), match: true},
+		{name: "nil not nil", matcher: match.Nil(), value: &p},
+		{name: "non zero", matcher: match.NonZero(), value: 1, match: true},
+		{name: "non zero zero", matcher: match.NonZero(), value: Product{}},
+		{name: "non empty", matcher: match.NonEmpty(), value: "a", match: true},
+		{name: "non empty empty slice", matcher: match.NonEmpty(), value: []int{}},
+		{name: "non empty int", matcher: match.NonEmpty(), value: 1},
+		{name: "not", matcher: match.Not(match.NonEmpty()), value: "", match: true},
+		{name: "any of", matcher: match.AnyOf("a", match.Regex("^b")), value: "bc", match: true},
+		{name: "any of none", matcher: match.AnyOf("a", "b"), value: "c"},
+		{name: "all of", matcher: match.AllOf(match.Gt(1), match.Lt(3)), value: 2, match: true},
+		{name: "all of not all", matcher: match.AllOf(match.Gt(1), match.Lt(3)), value: 3},
+		{name: "gt across numeric types", matcher: match.Gt(1), value: 1.5, match: true},
+		{name: "gt decimals", matcher: match.Gt(gotest.DS("1.49")), value: p.Price, match: true},
+		{name: "ge", matcher: match.Ge(uint8(2)), value: int64(2), match: true},
+		{name: "le times", matcher: match.Le(time.Unix(10, 0)), value: time.Unix(9, 0), match: true},
+		{name: "lt strings", matcher: match.Lt("b"), value: "a", match: true},
+		{name: "lt incomparable", matcher: match.Lt(1), value: "a"},
+		{name: "gt nan", matcher: match.Gt(1), value: math.NaN()},
+		{name: "lt nan", matcher: match.Lt(1), value: math.NaN()},
+		{name: "gt inf", matcher: match.Gt(1), value: math.Inf(1)},
+		{name: "lt negative inf", matcher: match.Lt(1), value: math.Inf(-1)},
+		{name: "ge inf bound", matcher: match.Ge(math.Inf(1)), value: 1},
+		{name: "le nan bound", matcher: match.Le(float32(math.NaN())), value: 1},
+		{name: "regex", matcher: match.Regex(`^\d+$`), value: "123", match: true},
+		{name: "regex stringer", matcher: match.Regex(`^1\.5`), value: p.Price, match: true},
+		{name: "regex no match", matcher: match.Regex(`^\d+$`), value: "12a"},
+		{name: "len", matcher: match.Len(2), value: p.Tags, match: true},
+		{name: "len matcher", matcher: match.Len(match.Gt(2)), value: "abc", match: true},
+		{name: "len wrong", matcher: match.Len(1), value: map[string]int{}},
+		{name: "field", matcher: match.Field("Price", match.DecimalEq("1.5")), value: p, match: true},
+		{name: "field of pointer", matcher: match.Field("Name", "tea"), value: &p, match: true},
+		{name: "field missing", matcher: match.Field("Colour", "red"), value: p},
+		{name: "field of nil pointer", matcher: match.Field("Name", "tea"), value: (*Product)(nil)},
+		{name: "each", matcher: match.Each(match.NonEmpty()), value: p.Tags, match: true},
+		{name: "each map", matcher: match.Each(match.Gt(0)), value: map[string]int{"a": 1, "b": 0}},
+		{name: "contains", matcher: match.Contains("hot"), value: p.Tags, match: true},
+		{name: "contains string", matcher: match.Contains("ea"), value: "tea", match: true},
+		{name: "contains missing", matcher: match.Contains("cold"), value: p.Tags},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			tfyassert.Equal(t, test.match, test.matcher.Match(test.value), test.matcher.String())
+		})
+	}
+}
+
+func TestMatchersInLogicallyEqual(t *testing.T) {
+
+	actual := Event{
+		ID: "3f1c2b8e",
+		Kind: "created",
+		At: time.Now(),
+		Product: &Product{Name: "tea", Price: gotest.DS("1.5")},
+	}
+
+	tfyassert.True(t, assert.LogicallyEqual(t, match.Fields(map[string]any{
+		"ID": match.NonEmpty(),
+		"Kind": "created",
+		"At": match.Gt(time.Unix(0, 0)),
+		"Product": &Product{Name: "tea", Price: gotest.DS("1.50")},
+	}), actual))
+	tfyassert.True(t, assert.LogicallyEqual(t, match.Fields(map[string]any{
+		"Product": match.Fields(map[string]any{"Price": match.DecimalEq("1.5")}),
+	}), &actual))
+	tfyassert.True(t, assert.LogicallyEqual(t, match.Field("Kind", "created"), actual))
+	tfyassert.True(t, assert.LogicallyEqual(
+		t,
+		match.Each(match.Fields(map[string]any{"Kind": "created"})),
+		[]Event{actual, actual},
+	))
+	tfyassert.True(t, assert.LogicallyEqual(t, map[string]any{"n": match.Gt(2)}, map[string]any{"n": 3}))
+	tfyassert.True(t, assert.LogicallyEqual(t, []any{match.Any(), "b"}, []any{1, "b"}))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.LogicallyEqual(&fakeT, match.Fields(map[string]any{
+		"ID": match.Regex(`^\d+$`),
+		"Kind": "created",
+	}), actual))
+	tfyassert.False(t, assert.LogicallyEqual(&fakeT, match.Fields(map[string]any{"Colour": "red"}), actual))
+	tfyassert.False(t, assert.LogicallyEqual(&fakeT, match.Fields(map[string]any{}), "not a struct"))
+	tfyassert.False(t, assert.LogicallyEqual(&fakeT, match.Field("Kind", "deleted"), actual))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestFieldsString(t *testing.T) {
+
+	m := match.Fields(map[string]any{"Seq": match.Gt(1), "Kind": "created"})
+	tfyassert.Equal(t, `fields {Kind: "created", Seq: > 1}`, m.String())
+}
+
+func TestMatchersInLogicallyContains(t *testing.T) {
+
+	actual := Event{
+		ID: "e-12",
+		Seq: 12,
+		Kind: "created",
+		Product: &Product{Name: "tea", Tags: []string{"hot"}},
+	}
+
+	tfyassert.True(t, assert.LogicallyContains(t, Event{Kind: "created"}, actual))
+	tfyassert.True(t, assert.LogicallyContains(t, match.Fields(map[string]any{"Seq": match.Gt(10)}), actual))
+	tfyassert.True(t, assert.LogicallyContains(t, match.Field("Product", match.Field("Tags", match.Len(1))), actual))
+
+	var fakeT testing.T
+	tfyassert.False(t, assert.LogicallyContains(&fakeT, match.Fields(map[string]any{"Seq": match.Lt(10)}), actual))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+type Catalogue struct {
+	tfymock.Mock
+}
+
+func (c *Catalogue) Save(p Product) error {
+	return c.Called(p).Error(0)
+}
+
+func TestArg(t *testing.T) {
+
+	var c Catalogue
+	c.On("Save", match.Arg(match.Field("Price", match.Gt(1)))).Return(nil).Once()
+	c.On("Save", match.Arg(match.Any())).Return(tfyassert.AnError)
+
+	tfyassert.NoError(t, c.Save(Product{Price: gotest.DS("1.5")}))
+	tfyassert.Error(t, c.Save(Product{Price: gotest.DS("0.5")}))
+	c.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tfymock "github.com/stretchr/testify/mock"
+)
+
+// Mock is embedded by the mocks generated by gotest-mock in place of the
+// testify mock. It records every call made to it and, when the mock was
+// created by `Auto`, allows calls which were not set up.
+type Mock struct {
+	tfymock.Mock
+
+	t *testing.T
+	generate bool
+	auto bool
+
+	mu sync.Mutex
+	recorded []RecordedCall
+}
+
+// RecordedCall is a call made to a `Mock`.
+type RecordedCall struct {
+	Method string
+	Args []any
+}
+
+// Init sets up `m` to assert that its expectations were met when the test
+// finishes; when `generate` is true, calls set up without results return
+// values generated by `rand.New`.
+func (m *Mock) Init(t *testing.T, generate bool) {
+
+	m.t = t
+	m.generate = generate
+	assertOnCleanup(t, &m.Mock)
+}
+
+// T returns the test `m` was initialised with.
+func (m *Mock) T() *testing.T {
+	return m.t
+}
+
+// Generates reports whether the results of calls which were not set up to
+// return any are generated by `rand.New`.
+func (m *Mock) Generates() bool {
+	return m.generate
+}
+
+// Called records the call to the method calling it and returns the results
+// it was set up with; see `MethodCalled`.
+func (m *Mock) Called(args ...any) tfymock.Arguments {
+
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		panic("gotest/mock: cannot find the name of the called method")
+	}
+	name := runtime.FuncForPC(pc).Name()
+	name = strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "-fm")
+	return m.MethodCalled(name, args...)
+}
+
+// MethodCalled records the call to `method` and returns the results it was
+// set up with. When `m` was created by `Auto` and no matching call was set
+// up, it returns no results.
+func (m *Mock) MethodCalled(method string, args ...any) tfymock.Arguments {
+
+	m.mu.Lock()
+	m.recorded = append(m.recorded, RecordedCall{
+		Method: method,
+		Args: args,
+	})
+	m.mu.Unlock()
+
+	if m.auto && !m.expects(method, args) {
+		return nil
+	}
+	return m.Mock.MethodCalled(method, args...)
+}
+
+// Recorded returns every call made to `m`, in the order they were made.
+func (m *Mock) Recorded() []RecordedCall {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]RecordedCall(nil), m.recorded...)
+}
+
+// RecordedTo returns the calls made to `method`.
+func (m *Mock) RecordedTo(method string) []RecordedCall {
+
+	var calls []RecordedCall
+	for _, c := range m.Recorded() {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+func (m *Mock) expects(method string, args []any) bool {
+
+	for _, c := range m.ExpectedCalls {
+		if c.Method != method || c.Repeatability < 0 {
+			continue
+		}
+		if _, diffs := c.Arguments.Diff(args); diffs == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Mock) gotestMock() *Mock {
+	return m
+}
+
+// gotestMocker is implemented by the types embedding `Mock`.
+type gotestMocker interface {
+	gotestMock() *Mock
+}
+
+var autoMocks sync.Map
+
+// Register registers the constructor of a generated mock of `Iface` for use
+// by `Auto`; generated mocks register themselves.
+func Register[Iface any](newMock func(t *testing.T) Iface) {
+	autoMocks.Store(reflect.TypeOf((*Iface)(nil)).Elem(), newMock)
+}
+
+// Auto returns a mock of `Iface`, which must have been generated by
+// gotest-mock, with `calls` set up as by `Expect`. Calls to methods which
+// were not set up return values generated by `rand.New` for their results,
+// with nil errors and interfaces, and every call is recorded; see `CallsOf`.
+func Auto[Iface any](t *testing.T, calls ...Call) Iface {
+
+	t.Helper()
+
+	typ := reflect.TypeOf((*Iface)(nil)).Elem()
+	newMock, ok := autoMocks.Load(typ)
+	if !ok {
+		require.Fail(t, fmt.Sprintf(
+			"gotest/mock: no mock of %s is registered; generate one with gotest-mock and import its package",
+			typ,
+		))
+	}
+
+	iface := newMock.(func(*testing.T) Iface)(t)
+	m := any(iface).(gotestMocker).gotestMock()
+	m.auto = true
+	m.generate = true
+	Expect(t, &m.Mock, calls...)
+	return iface
+}
+
+// CallsOf returns every call made to `mock`, a mock generated by
+// gotest-mock, in the order they were made.
+func CallsOf(mock any) []RecordedCall {
+
+	m, ok := mock.(gotestMocker)
+	if !ok {
+		panic(fmt.Sprintf("gotest/mock: %T is not a mock generated by gotest-mock", mock))
+	}
+	return m.gotestMock().Recorded()
+}
@@ -0,0 +1,66 @@
+package mock_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tfymock "github.com/stretchr/testify/mock"
+
+	"github.com/thecodedproject/gotest/cmd/gotest-mock/example"
+	"github.com/thecodedproject/gotest/mock"
+	gotest "github.com/thecodedproject/gotest/util"
+)
+
+func TestAuto(t *testing.T) {
+
+	ctx := context.Background()
+	s := mock.Auto[example.Store](t, mock.Call{
+		Method: "Get",
+		Args: []any{tfymock.Anything, "a"},
+		Returns: []any{example.Balance{Account: "a", Amount: gotest.DS("1")}, nil},
+	})
+
+	b, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "a", b.Account)
+
+	b, err = s.Get(ctx, "b")
+	require.NoError(t, err)
+	require.NotEqual(t, "a", b.Account)
+	require.NotEmpty(t, b.Account)
+
+	require.NoError(t, s.Put(ctx, b))
+	s.Accounts(ctx, "x", "y")
+	s.Close()
+
+	calls := mock.CallsOf(s)
+	require.Len(t, calls, 5)
+	require.Equal(t, "Get", calls[0].Method)
+	require.Equal(t, []any{ctx, "b"}, calls[1].Args)
+	require.Equal(t, "Put", calls[2].Method)
+	require.Equal(t, []any{ctx, []string{"x", "y"}}, calls[3].Args)
+	require.Equal(t, "Close", calls[4].Method)
+
+	require.Len(t, s.(*example.MockStore).RecordedTo("Get"), 2)
+}
+
+func TestAutoFailsForUnregisteredInterface(t *testing.T) {
+
+	var fakeT testing.T
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mock.Auto[io.Reader](&fakeT)
+	}()
+	<-done
+	require.True(t, fakeT.Failed())
+}
+
+func TestCallsOfPanicsForOtherTypes(t *testing.T) {
+
+	require.Panics(t, func() {
+		mock.CallsOf(&tfymock.Mock{})
+	})
+}
@@ -0,0 +1,166 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	tfymock "github.com/stretchr/testify/mock"
+
+	"github.com/thecodedproject/gotest/assert"
+)
+
+// Call is an expected call to a method of a mock.
+type Call struct {
+	Method string
+	// Args are the expected arguments, compared with `assert.LogicallyEqual`
+	// unless they are testify matchers such as `mock.Anything` or
+	// `mock.MatchedBy`.
+	Args []any
+	Returns []any
+	// Times is the number of times the call is expected; when 0 the call is
+	// expected at least once.
+	Times int
+}
+
+var (
+	cleanupsMu sync.Mutex
+	cleanups = make(map[*tfymock.Mock]bool)
+)
+
+// Expect sets up `calls` on `m`, in any order, and asserts that they were
+// all made when the test finishes.
+func Expect(t *testing.T, m *tfymock.Mock, calls ...Call) {
+
+	t.Helper()
+
+	for _, c := range calls {
+		on(m, c)
+	}
+	assertOnCleanup(t, m)
+}
+
+// ExpectInOrder sets up `calls` on `m` and asserts that they are made in the
+// order given, each one as many times as it is expected before the next, and
+// that they were all made when the test finishes.
+func ExpectInOrder(t *testing.T, m *tfymock.Mock, calls ...Call) {
+
+	t.Helper()
+
+	var mu sync.Mutex
+	counts := make([]int, len(calls))
+
+	for i, c := range calls {
+		i := i
+		on(m, c).Run(func(tfymock.Arguments) {
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			counts[i]++
+			for j := range calls {
+				if j < i && !satisfied(calls[j], counts[j]) {
+					t.Errorf(
+						"gotest/mock: %s called before %s was called %s",
+						describe(calls[i]),
+						describe(calls[j]),
+						expectedTimes(calls[j]),
+					)
+				} else if j > i && counts[j] > 0 {
+					t.Errorf(
+						"gotest/mock: %s called after %s",
+						describe(calls[i]),
+						describe(calls[j]),
+					)
+				}
+			}
+		})
+	}
+	assertOnCleanup(t, m)
+}
+
+func on(m *tfymock.Mock, c Call) *tfymock.Call {
+
+	args := make([]any, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = Arg(arg)
+	}
+
+	call := m.On(c.Method, args...).Return(c.Returns...)
+	if c.Times > 0 {
+		call.Times(c.Times)
+	}
+	return call
+}
+
+// Arg returns a testify matcher which compares arguments to `expected` with
+// `assert.LogicallyEqual`, or `expected` if it already is a testify matcher.
+func Arg(expected any) any {
+
+	if isTestifyMatcher(expected) {
+		return expected
+	}
+
+	return tfymock.MatchedBy(func(actual any) bool {
+		return assert.IsLogicallyEqual(expected, actual)
+	})
+}
+
+func isTestifyMatcher(v any) bool {
+
+	switch v := v.(type) {
+	case string:
+		return v == tfymock.Anything
+	case tfymock.AnythingOfTypeArgument, *tfymock.IsTypeArgument:
+		return true
+	case interface{ Matches(any) bool }:
+		// The unexported type returned by `tfymock.MatchedBy`
+		return true
+	}
+	return false
+}
+
+func assertOnCleanup(t *testing.T, m *tfymock.Mock) {
+
+	cleanupsMu.Lock()
+	defer cleanupsMu.Unlock()
+
+	if cleanups[m] {
+		return
+	}
+	cleanups[m] = true
+
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+
+		cleanupsMu.Lock()
+		delete(cleanups, m)
+		cleanupsMu.Unlock()
+	})
+}
+
+func satisfied(c Call, count int) bool {
+
+	if c.Times > 0 {
+		return count == c.Times
+	}
+	return count > 0
+}
+
+func expectedTimes(c Call) string {
+
+	if c.Times > 0 {
+		return fmt.Sprintf("%d time(s)", c.Times)
+	}
+	return "at least once"
+}
+
+func describe(c Call) string {
+
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = fmt.Sprintf("%v", arg)
+	}
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(args, ", "))
+}
@@ -0,0 +1,138 @@
+package mock_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+	tfymock "github.com/stretchr/testify/mock"
+
+	"github.com/thecodedproject/gotest/mock"
+	gotest "github.com/thecodedproject/gotest/util"
+)
+
+type Store struct {
+	tfymock.Mock
+}
+
+func (s *Store) Get(id string) (decimal.Decimal, error) {
+	args := s.Called(id)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (s *Store) Put(id string, v decimal.Decimal) error {
+	return s.Called(id, v).Error(0)
+}
+
+func TestExpect(t *testing.T) {
+
+	var s Store
+	mock.Expect(t, &s.Mock,
+		mock.Call{
+			Method: "Put",
+			Args: []any{"a", gotest.DS("1.50")},
+			Returns: []any{nil},
+		},
+		mock.Call{
+			Method: "Get",
+			Args: []any{"a"},
+			Returns: []any{gotest.DS("1.5"), nil},
+			Times: 2,
+		},
+		mock.Call{
+			Method: "Put",
+			Args: []any{tfymock.Anything, tfymock.AnythingOfType("decimal.Decimal")},
+			Returns: []any{nil},
+		},
+	)
+
+	tfyassert.NoError(t, s.Put("a", gotest.DI(15, -1)))
+	v, err := s.Get("a")
+	tfyassert.NoError(t, err)
+	tfyassert.True(t, v.Equal(gotest.DS("1.5")))
+	s.Get("a")
+	s.Put("b", gotest.DS("2"))
+}
+
+func TestExpectMatchedBy(t *testing.T) {
+
+	var s Store
+	mock.Expect(t, &s.Mock, mock.Call{
+		Method: "Put",
+		Args: []any{
+			tfymock.MatchedBy(func(id string) bool { return len(id) == 3 }),
+			gotest.DS("1"),
+		},
+		Returns: []any{nil},
+	})
+
+	s.Put("abc", gotest.DS("1.000"))
+}
+
+func TestExpectIsType(t *testing.T) {
+
+	var s Store
+	mock.Expect(t, &s.Mock, mock.Call{
+		Method: "Put",
+		Args: []any{tfymock.IsType(""), tfymock.IsType(gotest.DS("0"))},
+		Returns: []any{nil},
+	})
+
+	s.Put("abc", gotest.DS("1"))
+}
+
+func TestExpectInOrder(t *testing.T) {
+
+	calls := []mock.Call{
+		{
+			Method: "Put",
+			Args: []any{"a", gotest.DS("1")},
+			Returns: []any{nil},
+		},
+		{
+			Method: "Get",
+			Args: []any{"a"},
+			Returns: []any{gotest.DS("1"), nil},
+			Times: 2,
+		},
+		{
+			Method: "Put",
+			Args: []any{"a", gotest.DS("2")},
+			Returns: []any{nil},
+		},
+	}
+
+	t.Run("in order", func(t *testing.T) {
+		var s Store
+		mock.ExpectInOrder(t, &s.Mock, calls...)
+
+		s.Put("a", gotest.DS("1"))
+		s.Get("a")
+		s.Get("a")
+		s.Put("a", gotest.DS("2"))
+	})
+
+	t.Run("called before an earlier call was satisfied", func(t *testing.T) {
+		var fakeT testing.T
+		var s Store
+		mock.ExpectInOrder(&fakeT, &s.Mock, calls...)
+
+		s.Put("a", gotest.DS("1"))
+		s.Get("a")
+		tfyassert.False(t, fakeT.Failed())
+		s.Put("a", gotest.DS("2"))
+		tfyassert.True(t, fakeT.Failed())
+	})
+
+	t.Run("called after a later call", func(t *testing.T) {
+		var fakeT testing.T
+		var s Store
+		mock.ExpectInOrder(&fakeT, &s.Mock, calls[0], calls[2])
+
+		s.Put("a", gotest.DS("1"))
+		s.Put("a", gotest.DS("2"))
+		tfyassert.False(t, fakeT.Failed())
+		s.Put("a", gotest.DS("1"))
+		tfyassert.True(t, fakeT.Failed())
+	})
+}
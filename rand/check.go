@@ -0,0 +1,159 @@
+package rand
+
+import (
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+const (
+	defaultRuns = 100
+)
+
+// CheckOption configures how `Check` checks a property: `Runs`, `Seed` and
+// `NoShrink`, or any `Option` to apply to the generated inputs.
+type CheckOption interface {
+	applyCheck(o *checkOptions)
+}
+
+type checkOption func(*checkOptions)
+
+func (opt checkOption) applyCheck(o *checkOptions) {
+	opt(o)
+}
+
+func (opt Option) applyCheck(o *checkOptions) {
+	o.genOpts = append(o.genOpts, opt)
+}
+
+type checkOptions struct {
+	runs int
+	seed int64
+	hasSeed bool
+	noShrink bool
+	genOpts []Option
+}
+
+// Runs sets the number of generated inputs a property is checked against.
+func Runs(n int) CheckOption {
+	return checkOption(func(o *checkOptions) {
+		o.runs = n
+	})
+}
+
+// Seed sets the seed of the first run of a property; run `i` uses `seed+i`.
+func Seed(seed int64) CheckOption {
+	return checkOption(func(o *checkOptions) {
+		o.seed = seed
+		o.hasSeed = true
+	})
+}
+
+// Check runs `prop` as a subtest against inputs generated with `NewFromSeed`.
+//
+// Checking stops at the first failing input, reporting the seed it was
+// generated from; `rand.Check(t, prop, rand.Seed(seed), rand.Runs(1))` (or
+// `rand.NewFromSeed[Type](t, seed)`) reproduces it. Unless `NoShrink` is
+// given, the failing input is then shrunk by re-running `prop` against
+// simpler inputs, each in a subtest of a "shrink" subtest, and the minimal
+// counterexample is reported as well. Each run waits for `prop` to finish,
+// even when it calls `t.Parallel`.
+func Check[Type any](
+	t *testing.T,
+	prop func(t *testing.T, in Type),
+	opts ...CheckOption,
+) {
+
+	t.Helper()
+
+	o := &checkOptions{
+		runs: defaultRuns,
+	}
+	for _, opt := range opts {
+		opt.applyCheck(o)
+	}
+	genOpts := newOptions(t, o.genOpts)
+
+	if o.runs < 1 {
+		t.Fatalf("gotest/rand: number of runs must be at least 1; got %d", o.runs)
+	}
+
+	seed := o.seed
+	if !o.hasSeed {
+		seed = time.Now().UnixNano()
+	}
+
+	for i:=0; i<o.runs; i++ {
+		runSeed := seed + int64(i)
+		in := NewFromSeed[Type](t, runSeed, o.genOpts...)
+
+		ok := runPropSubtest(t, fmt.Sprintf("run_%d", i), prop, in)
+
+		if !ok {
+			from := fmt.Sprintf("with seed %d", runSeed)
+			if genOpts.source != nil {
+				from = "from a custom source"
+			}
+			msg := fmt.Sprintf(
+				"gotest/rand: property failed on run %d %s\ninput:\n%s",
+				i,
+				from,
+				FormatPaths(in),
+			)
+			if !o.noShrink {
+				var shrunk Type
+				t.Run("shrink", func(t *testing.T) {
+					shrunk = shrinkProp(t, prop, in)
+				})
+				msg += "\nshrunk input:\n" + FormatPaths(shrunk)
+			}
+			t.Error(msg)
+			return
+		}
+	}
+}
+
+// runProp runs `prop` against `in`, reporting a panic in `prop` as a failure
+// of `t`.
+func runProp[Type any](t *testing.T, prop func(t *testing.T, in Type), in Type) {
+
+	defer func() {
+		if p := recover(); p != nil {
+			t.Errorf("panic: %v\n%s", p, debug.Stack())
+		}
+	}()
+	prop(t, in)
+}
+
+// runPropSubtest runs `prop` against `in` in the subtest `name` of `t` and
+// reports whether it passed. The property runs in a nested subtest, which
+// `t.Run` waits for even when the property calls `t.Parallel`.
+func runPropSubtest[Type any](
+	t *testing.T,
+	name string,
+	prop func(t *testing.T, in Type),
+	in Type,
+) bool {
+
+	return t.Run(name, func(t *testing.T) {
+		t.Run("prop", func(t *testing.T) {
+			runProp(t, prop, in)
+		})
+	})
+}
+
+// shrinkProp shrinks the failing input `in`, running `prop` against each
+// candidate in a subtest of `t`.
+func shrinkProp[Type any](
+	t *testing.T,
+	prop func(t *testing.T, in Type),
+	in Type,
+) Type {
+
+	candidate := 0
+	return Shrink(in, func(in Type) bool {
+		candidate++
+		return !runPropSubtest(t, fmt.Sprintf("candidate_%d", candidate), prop, in)
+	})
+}
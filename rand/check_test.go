@@ -0,0 +1,174 @@
+package rand_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+const (
+	failingCheckEnv = "GOTEST_RAND_FAILING_CHECK"
+	failingCheckSeedEnv = "GOTEST_RAND_FAILING_CHECK_SEED"
+)
+
+// failingChecks are run by `TestFailingCheck` in a child test binary, as a
+// failing `Check` fails the test which runs it.
+var failingChecks = map[string]func(t *testing.T, opts ...rand.CheckOption){
+	"always fails": func(t *testing.T, opts ...rand.CheckOption) {
+		rand.Check(t, func(t *testing.T, in MyStruct) {
+			t.Error("property failed")
+		}, opts...)
+	},
+	"uses subtests and cleanups": func(t *testing.T, opts ...rand.CheckOption) {
+		var registered, ran int
+		rand.Check(t, func(t *testing.T, in CheckInput) {
+			registered++
+			t.Cleanup(func() {
+				ran++
+			})
+			t.TempDir()
+			t.Run("a", func(t *testing.T) {
+				t.Parallel()
+				if in.A > 10 {
+					t.Error("a is greater than 10")
+				}
+			})
+		}, opts...)
+		fmt.Printf("cleanups registered: %d, ran: %d\n", registered, ran)
+	},
+	"runs in parallel": func(t *testing.T, opts ...rand.CheckOption) {
+		rand.Check(t, func(t *testing.T, in CheckInput) {
+			t.Parallel()
+			if in.A > 10 {
+				t.Error("a is greater than 10")
+			}
+		}, opts...)
+	},
+}
+
+type CheckInput struct {
+	A int
+	B string
+}
+
+func TestCheck(t *testing.T) {
+
+	t.Run("runs property once per run", func(t *testing.T) {
+		var count int
+		rand.Check(t, func(t *testing.T, in MyStruct) {
+			count++
+		}, rand.Runs(17))
+		require.Equal(t, 17, count)
+	})
+
+	t.Run("default number of runs", func(t *testing.T) {
+		var count int
+		rand.Check(t, func(t *testing.T, in int64) {
+			count++
+		})
+		require.Equal(t, 100, count)
+	})
+
+	t.Run("inputs are generated from consecutive seeds", func(t *testing.T) {
+		var inputs []MyNestedStruct
+		rand.Check(t, func(t *testing.T, in MyNestedStruct) {
+			inputs = append(inputs, in)
+		}, rand.Seed(1234), rand.Runs(3))
+
+		require.Len(t, inputs, 3)
+		for i, in := range inputs {
+			require.Equal(t, rand.NewFromSeed[MyNestedStruct](t, 1234+int64(i)), in)
+		}
+	})
+
+	t.Run("generation options apply to inputs", func(t *testing.T) {
+		var count int
+		rand.Check(t, func(t *testing.T, in Order) {
+			count++
+			require.Equal(t, "open", in.Status)
+			require.NotZero(t, in.ID)
+		}, rand.Runs(5), rand.With(func(o *Order) { o.Status = "open" }))
+		require.Equal(t, 5, count)
+	})
+
+	t.Run("failing property reports seed and input which reproduce it", func(t *testing.T) {
+		out := runFailingCheck(t, "always fails", "")
+
+		seedMatch := regexp.MustCompile(`property failed on run 0 with seed (-?\d+)`).FindStringSubmatch(out)
+		require.NotNil(t, seedMatch, out)
+		seed, err := strconv.ParseInt(seedMatch[1], 10, 64)
+		require.NoError(t, err)
+
+		input := rand.FormatPaths(rand.NewFromSeed[MyStruct](t, seed))
+		require.Contains(t, out, "\ninput:\n" + input + "\n", out)
+		require.Contains(t, out, "\nshrunk input:\n.Exported: \"\"\n.unexported: \"\"", out)
+
+		reproduced := runFailingCheck(t, "always fails", seedMatch[1])
+		require.Contains(t, reproduced, "property failed on run 0 with seed " + seedMatch[1], reproduced)
+		require.Contains(t, reproduced, "\ninput:\n" + input + "\n", reproduced)
+	})
+
+	t.Run("failing input is shrunk by running property in subtests", func(t *testing.T) {
+		out := runFailingCheck(t, "uses subtests and cleanups", "")
+
+		require.Contains(t, out, "\nshrunk input:\n.A: 11\n.B: \"\"\n", out)
+
+		counts := regexp.MustCompile(`cleanups registered: (\d+), ran: (\d+)`).FindStringSubmatch(out)
+		require.NotNil(t, counts, out)
+		require.Equal(t, counts[1], counts[2])
+	})
+
+	t.Run("failing parallel property reports seed and shrunk input", func(t *testing.T) {
+		out := runFailingCheck(t, "runs in parallel", "")
+
+		seedMatch := regexp.MustCompile(`property failed on run (\d+) with seed (-?\d+)`).FindStringSubmatch(out)
+		require.NotNil(t, seedMatch, out)
+		require.Equal(t, 1, strings.Count(out, "property failed on run"), out)
+		require.Contains(t, out, "\nshrunk input:\n.A: 11\n.B: \"\"\n", out)
+	})
+}
+
+func TestFailingCheck(t *testing.T) {
+
+	name := os.Getenv(failingCheckEnv)
+	if name == "" {
+		t.Skip("only run in a child test binary by runFailingCheck")
+	}
+
+	var opts []rand.CheckOption
+	if s := os.Getenv(failingCheckSeedEnv); s != "" {
+		seed, err := strconv.ParseInt(s, 10, 64)
+		require.NoError(t, err)
+		opts = append(opts, rand.Seed(seed), rand.Runs(1))
+	}
+	failingChecks[name](t, opts...)
+}
+
+// runFailingCheck runs the failing check `name` in a child test binary, with
+// `seed` if it is not empty, and returns its output with the indentation of
+// test logs removed.
+func runFailingCheck(t *testing.T, name string, seed string) string {
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFailingCheck$")
+	cmd.Env = append(
+		os.Environ(),
+		fmt.Sprintf("%s=%s", failingCheckEnv, name),
+		fmt.Sprintf("%s=%s", failingCheckSeedEnv, seed),
+	)
+	out, err := cmd.CombinedOutput()
+	require.Error(t, err, "check %q did not fail:\n%s", name, out)
+
+	lines := strings.Split(string(out), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
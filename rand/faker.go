@@ -0,0 +1,241 @@
+package rand
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	tagName = "rand"
+)
+
+var (
+	generatorsMu sync.RWMutex
+	generators = make(map[reflect.Type]func(r *rand.Rand) reflect.Value)
+
+	tagGeneratorsMu sync.RWMutex
+	tagGenerators = map[string]func(r *rand.Rand) string{
+		"first_name": FirstName,
+		"last_name": LastName,
+		"name": Name,
+		"email": Email,
+		"phone": Phone,
+		"address": Address,
+		"url": URL,
+		"uuid": UUID,
+		"word": Word,
+		"sentence": Sentence,
+		"paragraph": Paragraph,
+		"ipv4": IPv4,
+		"ipv6": IPv6,
+		"currency": CurrencyCode,
+	}
+)
+
+// RegisterGenerator makes `gen` produce every value of `Type` generated by
+// this package, e.g. from an `init` function or `TestMain`. Use
+// `RegisterGeneratorForTest` to register a generator for a single test, or
+// `WithGenerator` for a single call.
+func RegisterGenerator[Type any](gen func(r *rand.Rand) Type) {
+
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+	generators[typeOf[Type]()] = reflectGenerator(gen)
+}
+
+// RegisterGeneratorForTest makes `gen` produce every value of `Type`
+// generated by this package until `t` finishes, when the generator which was
+// registered before, if any, is restored.
+func RegisterGeneratorForTest[Type any](t testing.TB, gen func(r *rand.Rand) Type) {
+
+	typ := typeOf[Type]()
+
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+	prev, hadPrev := generators[typ]
+	generators[typ] = reflectGenerator(gen)
+
+	t.Cleanup(func() {
+		generatorsMu.Lock()
+		defer generatorsMu.Unlock()
+		if hadPrev {
+			generators[typ] = prev
+		} else {
+			delete(generators, typ)
+		}
+	})
+}
+
+// RegisterTag makes string fields tagged with `rand:"<name>"` be generated
+// by `gen`.
+func RegisterTag(name string, gen func(r *rand.Rand) string) {
+
+	tagGeneratorsMu.Lock()
+	defer tagGeneratorsMu.Unlock()
+	tagGenerators[name] = gen
+}
+
+// WithGenerator makes `gen` produce every value of `Type` generated by this
+// call, taking precedence over generators added with `RegisterGenerator`.
+func WithGenerator[Type any](gen func(r *rand.Rand) Type) Option {
+	return func(o *options) {
+		if o.generators == nil {
+			o.generators = make(map[reflect.Type]func(r *rand.Rand) reflect.Value)
+		}
+		o.generators[typeOf[Type]()] = reflectGenerator(gen)
+	}
+}
+
+func FirstName(r *rand.Rand) string {
+	return pick(r, firstNames)
+}
+
+func LastName(r *rand.Rand) string {
+	return pick(r, lastNames)
+}
+
+func Name(r *rand.Rand) string {
+	return FirstName(r) + " " + LastName(r)
+}
+
+func Email(r *rand.Rand) string {
+	return fmt.Sprintf(
+		"%s.%s%d@%s",
+		emailPart(FirstName(r)),
+		emailPart(LastName(r)),
+		r.Intn(100),
+		pick(r, emailDomains),
+	)
+}
+
+// Phone returns a number in the North American format using the 555-01XX
+// range reserved for fictional use.
+func Phone(r *rand.Rand) string {
+	return fmt.Sprintf("+1-%03d-555-01%02d", r.Intn(800) + 200, r.Intn(100))
+}
+
+func Address(r *rand.Rand) string {
+	return fmt.Sprintf(
+		"%d %s %s, %s",
+		r.Intn(999) + 1,
+		pick(r, streetNames),
+		pick(r, streetSuffixes),
+		pick(r, cities),
+	)
+}
+
+func URL(r *rand.Rand) string {
+	return fmt.Sprintf(
+		"https://%s.%s/%s/%s",
+		Word(r),
+		pick(r, emailDomains),
+		Word(r),
+		Word(r),
+	)
+}
+
+// UUID returns a random (version 4) UUID.
+func UUID(r *rand.Rand) string {
+
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(r.Intn(256))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func Word(r *rand.Rand) string {
+	return pick(r, loremWords)
+}
+
+func Sentence(r *rand.Rand) string {
+
+	words := make([]string, r.Intn(8) + 4)
+	for i := range words {
+		words[i] = Word(r)
+	}
+	s := strings.Join(words, " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+func Paragraph(r *rand.Rand) string {
+
+	sentences := make([]string, r.Intn(4) + 3)
+	for i := range sentences {
+		sentences[i] = Sentence(r)
+	}
+	return strings.Join(sentences, " ")
+}
+
+func IPv4(r *rand.Rand) string {
+	return fmt.Sprintf(
+		"%d.%d.%d.%d",
+		r.Intn(223) + 1,
+		r.Intn(256),
+		r.Intn(256),
+		r.Intn(254) + 1,
+	)
+}
+
+func IPv6(r *rand.Rand) string {
+
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("%x", r.Intn(1 << 16))
+	}
+	return strings.Join(groups, ":")
+}
+
+// CurrencyCode returns an active ISO 4217 currency code.
+func CurrencyCode(r *rand.Rand) string {
+	return pick(r, currencyCodes)
+}
+
+func pick(r *rand.Rand, from []string) string {
+	return from[r.Intn(len(from))]
+}
+
+func emailPart(s string) string {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, " ", "")
+	return strings.ReplaceAll(s, "'", "")
+}
+
+func typeOf[Type any]() reflect.Type {
+	return reflect.TypeOf((*Type)(nil)).Elem()
+}
+
+func reflectGenerator[Type any](gen func(r *rand.Rand) Type) func(r *rand.Rand) reflect.Value {
+	return func(r *rand.Rand) reflect.Value {
+		v := gen(r)
+		return reflect.ValueOf(&v).Elem()
+	}
+}
+
+// lookupGenerator returns the generator for `typ`, preferring one given by
+// `WithGenerator` over one added with `RegisterGenerator`.
+func lookupGenerator(o *options, typ reflect.Type) (func(r *rand.Rand) reflect.Value, bool) {
+
+	if gen, ok := o.generators[typ]; ok {
+		return gen, true
+	}
+
+	generatorsMu.RLock()
+	defer generatorsMu.RUnlock()
+	gen, ok := generators[typ]
+	return gen, ok
+}
+
+func lookupTag(name string) (func(r *rand.Rand) string, bool) {
+
+	tagGeneratorsMu.RLock()
+	defer tagGeneratorsMu.RUnlock()
+	gen, ok := tagGenerators[name]
+	return gen, ok
+}
@@ -0,0 +1,85 @@
+package rand
+
+var (
+	firstNames = []string{
+		"Aaliyah", "Adam", "Aisha", "Alejandro", "Alice", "Amara", "Andrei",
+		"Anna", "Arjun", "Ben", "Carlos", "Chen", "Chloe", "Daniel", "David",
+		"Elena", "Emily", "Emma", "Fatima", "Felix", "Grace", "Hana", "Hiro",
+		"Ibrahim", "Isabella", "Ivan", "James", "Jin", "Julia", "Kai",
+		"Kofi", "Lara", "Leila", "Liam", "Lucas", "Maria", "Mateo", "Maya",
+		"Mei", "Mohammed", "Nadia", "Noah", "Olga", "Omar", "Priya", "Ravi",
+		"Rosa", "Sakura", "Samuel", "Sara", "Sofia", "Tariq", "Thomas",
+		"Yara", "Yusuf", "Zanele", "Zoe",
+	}
+
+	lastNames = []string{
+		"Adeyemi", "Ahmed", "Andersen", "Brown", "Chen", "Costa", "Da Silva",
+		"Dubois", "Fernandez", "Fischer", "Garcia", "Gonzalez", "Haddad",
+		"Hansen", "Ivanova", "Jansen", "Jones", "Kaur", "Kim", "Kowalski",
+		"Kumar", "Lee", "Lopez", "Martin", "Mensah", "Meyer", "Mokoena",
+		"Moreau", "Muller", "Nakamura", "Nguyen", "Novak", "O'Brien",
+		"Okafor", "Patel", "Petrov", "Rossi", "Sato", "Schmidt", "Silva",
+		"Singh", "Smith", "Tanaka", "Taylor", "Wang", "Williams", "Wilson",
+		"Yilmaz", "Zhang",
+	}
+
+	streetNames = []string{
+		"Acacia", "Bridge", "Castle", "Cedar", "Chapel", "Church", "Elm",
+		"Forest", "Garden", "High", "Hill", "King", "Lake", "Maple", "Market",
+		"Mill", "North", "Oak", "Park", "Pine", "Queen", "River", "School",
+		"South", "Station", "Victoria", "Water", "West", "Willow",
+	}
+
+	streetSuffixes = []string{
+		"Avenue", "Close", "Court", "Drive", "Lane", "Place", "Road",
+		"Street", "Way",
+	}
+
+	cities = []string{
+		"Amsterdam", "Auckland", "Bangalore", "Berlin", "Bogota", "Boston",
+		"Buenos Aires", "Cape Town", "Chicago", "Dublin", "Edinburgh",
+		"Helsinki", "Istanbul", "Johannesburg", "Lagos", "Lisbon", "London",
+		"Madrid", "Melbourne", "Mexico City", "Montreal", "Mumbai", "Nairobi",
+		"Osaka", "Oslo", "Paris", "Prague", "Rome", "Santiago", "Seoul",
+		"Singapore", "Stockholm", "Sydney", "Tokyo", "Toronto", "Vienna",
+		"Warsaw", "Zurich",
+	}
+
+	// emailDomains are reserved for documentation by RFC 2606, so generated
+	// addresses can never reach a real mailbox.
+	emailDomains = []string{
+		"example.com", "example.net", "example.org",
+	}
+
+	loremWords = []string{
+		"a", "ac", "adipiscing", "aliqua", "aliquip", "amet", "anim", "aute",
+		"cillum", "commodo", "consectetur", "consequat", "culpa", "cupidatat",
+		"deserunt", "do", "dolor", "dolore", "duis", "ea", "eiusmod", "elit",
+		"enim", "esse", "est", "et", "eu", "ex", "excepteur", "exercitation",
+		"fugiat", "id", "in", "incididunt", "ipsum", "irure", "labore",
+		"laboris", "laborum", "lorem", "magna", "minim", "mollit", "nisi",
+		"non", "nostrud", "nulla", "occaecat", "officia", "pariatur",
+		"proident", "qui", "quis", "reprehenderit", "sed", "sint", "sit",
+		"sunt", "tempor", "ullamco", "ut", "velit", "veniam", "voluptate",
+	}
+
+	// currencyCodes are the active ISO 4217 currency codes.
+	currencyCodes = []string{
+		"AED", "AFN", "ALL", "AMD", "AOA", "ARS", "AUD", "AWG", "AZN", "BAM",
+		"BBD", "BDT", "BGN", "BHD", "BIF", "BMD", "BND", "BOB", "BRL", "BSD",
+		"BTN", "BWP", "BYN", "BZD", "CAD", "CDF", "CHF", "CLP", "CNY", "COP",
+		"CRC", "CUP", "CVE", "CZK", "DJF", "DKK", "DOP", "DZD", "EGP", "ERN",
+		"ETB", "EUR", "FJD", "FKP", "GBP", "GEL", "GHS", "GIP", "GMD", "GNF",
+		"GTQ", "GYD", "HKD", "HNL", "HTG", "HUF", "IDR", "ILS", "INR", "IQD",
+		"IRR", "ISK", "JMD", "JOD", "JPY", "KES", "KGS", "KHR", "KMF", "KPW",
+		"KRW", "KWD", "KYD", "KZT", "LAK", "LBP", "LKR", "LRD", "LSL", "LYD",
+		"MAD", "MDL", "MGA", "MKD", "MMK", "MNT", "MOP", "MRU", "MUR", "MVR",
+		"MWK", "MXN", "MYR", "MZN", "NAD", "NGN", "NIO", "NOK", "NPR", "NZD",
+		"OMR", "PAB", "PEN", "PGK", "PHP", "PKR", "PLN", "PYG", "QAR", "RON",
+		"RSD", "RUB", "RWF", "SAR", "SBD", "SCR", "SDG", "SEK", "SGD", "SHP",
+		"SLE", "SOS", "SRD", "SSP", "STN", "SVC", "SYP", "SZL", "THB", "TJS",
+		"TMT", "TND", "TOP", "TRY", "TTD", "TWD", "TZS", "UAH", "UGX", "USD",
+		"UYU", "UZS", "VES", "VND", "VUV", "WST", "XAF", "XCD", "XOF", "XPF",
+		"YER", "ZAR", "ZMW",
+	}
+)
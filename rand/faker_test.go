@@ -0,0 +1,168 @@
+package rand_test
+
+import (
+	mathrand "math/rand"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+type Person struct {
+	Name string `rand:"name"`
+	Email string `rand:"email"`
+	Phone string `rand:"phone"`
+	Address string `rand:"address"`
+	Website string `rand:"url"`
+	ID string `rand:"uuid"`
+	Bio string `rand:"paragraph"`
+	IP string `rand:"ipv4"`
+	IPs []string `rand:"ipv6"`
+	Currency *string `rand:"currency"`
+	Nickname string
+	Pet Pet
+}
+
+type Pet struct {
+	Name string `rand:"first_name"`
+	Colour string
+}
+
+type Colour string
+
+type Palette struct {
+	Primary Colour
+	Others []Colour
+}
+
+func TestFakerTags(t *testing.T) {
+
+	uuidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	hexRegex := regexp.MustCompile(`^[0-9a-f]+$`)
+
+	for seed := int64(0); seed < 20; seed++ {
+		p := rand.NewFromSeed[Person](t, seed)
+
+		require.Contains(t, p.Name, " ")
+		_, err := mail.ParseAddress(p.Email)
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(p.Email, ".com") || strings.HasSuffix(p.Email, ".net") || strings.HasSuffix(p.Email, ".org"))
+		require.Regexp(t, `^\+1-\d{3}-555-01\d{2}$`, p.Phone)
+		require.Regexp(t, `^\d+ \w+ \w+, `, p.Address)
+		u, err := url.Parse(p.Website)
+		require.NoError(t, err)
+		require.Equal(t, "https", u.Scheme)
+		require.Regexp(t, uuidRegex, p.ID)
+		require.True(t, strings.HasSuffix(p.Bio, "."))
+		require.NotNil(t, net.ParseIP(p.IP).To4())
+		require.NotEmpty(t, p.IPs)
+		for _, ip := range p.IPs {
+			require.NotNil(t, net.ParseIP(ip))
+		}
+		require.Len(t, *p.Currency, 3)
+		require.Regexp(t, hexRegex, p.Nickname)
+		require.Regexp(t, `^[A-Z]`, p.Pet.Name)
+		require.Regexp(t, hexRegex, p.Pet.Colour)
+	}
+}
+
+func TestFakerIsDeterministic(t *testing.T) {
+
+	a := rand.NewFromSeed[Person](t, 1234)
+	b := rand.NewFromSeed[Person](t, 1234)
+	require.Equal(t, a, b)
+
+	r := mathrand.New(mathrand.NewSource(1234))
+	email := rand.Email(r)
+	r = mathrand.New(mathrand.NewSource(1234))
+	require.Equal(t, email, rand.Email(r))
+}
+
+func TestFakerUnknownTag(t *testing.T) {
+
+	requireFails(t, func(t *testing.T) {
+		rand.New[struct {
+			Field string `rand:"not_a_generator"`
+		}](t)
+	})
+}
+
+func TestRegisterTag(t *testing.T) {
+
+	rand.RegisterTag("test_planet", func(r *mathrand.Rand) string {
+		return []string{"mercury", "venus", "earth"}[r.Intn(3)]
+	})
+
+	actual := rand.New[struct {
+		Planet string `rand:"test_planet"`
+	}](t)
+	require.Contains(t, []string{"mercury", "venus", "earth"}, actual.Planet)
+}
+
+func TestGenerators(t *testing.T) {
+
+	t.Run("with generator", func(t *testing.T) {
+		actual := rand.New[Palette](t, rand.WithGenerator(func(r *mathrand.Rand) Colour {
+			return "red"
+		}))
+		require.Equal(t, Colour("red"), actual.Primary)
+		require.NotEmpty(t, actual.Others)
+		for _, c := range actual.Others {
+			require.Equal(t, Colour("red"), c)
+		}
+	})
+
+	t.Run("generator registered for test", func(t *testing.T) {
+		t.Run("is used during the test", func(t *testing.T) {
+			rand.RegisterGeneratorForTest(t, func(r *mathrand.Rand) Colour {
+				return "registered"
+			})
+
+			actual := rand.New[Palette](t)
+			require.Equal(t, Colour("registered"), actual.Primary)
+		})
+
+		actual := rand.New[Palette](t)
+		require.NotEqual(t, Colour("registered"), actual.Primary)
+	})
+
+	t.Run("generator registered for test restores previous generator", func(t *testing.T) {
+		rand.RegisterGeneratorForTest(t, func(r *mathrand.Rand) Colour {
+			return "outer"
+		})
+
+		t.Run("inner", func(t *testing.T) {
+			rand.RegisterGeneratorForTest(t, func(r *mathrand.Rand) Colour {
+				return "inner"
+			})
+			require.Equal(t, Colour("inner"), rand.New[Palette](t).Primary)
+		})
+
+		require.Equal(t, Colour("outer"), rand.New[Palette](t).Primary)
+	})
+
+	t.Run("with generator takes precedence over registered generator", func(t *testing.T) {
+		rand.RegisterGeneratorForTest(t, func(r *mathrand.Rand) Colour {
+			return "registered"
+		})
+
+		actual := rand.New[Palette](t, rand.WithGenerator(func(r *mathrand.Rand) Colour {
+			return "blue"
+		}))
+		require.Equal(t, Colour("blue"), actual.Primary)
+	})
+
+	t.Run("zero only keeps non-zero values", func(t *testing.T) {
+		actual := Palette{Primary: "green"}
+		rand.Fill(t, &actual, rand.ZeroOnly(), rand.WithGenerator(func(r *mathrand.Rand) Colour {
+			return "blue"
+		}))
+		require.Equal(t, Colour("green"), actual.Primary)
+	})
+}
@@ -0,0 +1,36 @@
+package rand
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// FromBytes decodes `data` into a value of `Type` using the same reflective
+// walk as `New`, with `data` as the entropy source instead of a seeded
+// `math/rand` stream.
+//
+// The decoding is deterministic and once `data` is exhausted every further
+// random value is zero, so maps may have fewer entries than were drawn, and
+// `go test -fuzz` can explore structured inputs and minimise the corpus:
+//
+//	func FuzzRoundTrip(f *testing.F) {
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			in := rand.FromBytes[Order](t, data)
+//			...
+//		})
+//	}
+func FromBytes[Type any](t testing.TB, data []byte, opts ...Option) Type {
+	var toFill Type
+	FillFromBytes(t, &toFill, data, opts...)
+	return toFill
+}
+
+func FillFromBytes(t testing.TB, toFill any, data []byte, opts ...Option) {
+	o := newOptions(t, opts)
+	o.source = ReaderSource(bytes.NewReader(data))
+	o.fromBytes = true
+	r := newRand(o, 0)
+	v := reflect.ValueOf(toFill)
+	fillValue(t, v, r, o, "")
+}
@@ -0,0 +1,64 @@
+package rand_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+func TestFromBytes(t *testing.T) {
+
+	t.Run("same bytes decode to same value", func(t *testing.T) {
+		data := []byte("some fuzzer provided bytes which are long enough")
+		a := rand.FromBytes[MyNestedStruct](t, data)
+		b := rand.FromBytes[MyNestedStruct](t, data)
+		require.Equal(t, a, b)
+	})
+
+	t.Run("different bytes decode to different values", func(t *testing.T) {
+		a := rand.FromBytes[MyStruct](t, []byte{1})
+		b := rand.FromBytes[MyStruct](t, []byte{2})
+		require.NotEqual(t, a, b)
+	})
+
+	t.Run("empty bytes decode to zero random values", func(t *testing.T) {
+		actual := rand.FromBytes[ShrinkStruct](t, nil)
+		require.Equal(t, ShrinkStruct{
+			Name: "0",
+			Tags: []string{"0"},
+			Attrs: map[string]int{"0": 0},
+			Nested: &MyStruct{Exported: "0", unexported: "0"},
+		}, actual)
+	})
+
+	t.Run("bytes are consumed eight at a time", func(t *testing.T) {
+		actual := rand.FromBytes[[2]uint64](t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 2})
+		require.Equal(t, [2]uint64{1, 2}, actual)
+	})
+
+	t.Run("maps keep their distinct keys once bytes run out", func(t *testing.T) {
+		actual := rand.FromBytes[ShrinkStruct](t, []byte("0000000000000000000000000000000000001"))
+		require.NotEmpty(t, actual.Attrs)
+	})
+
+	t.Run("fill", func(t *testing.T) {
+		var actual [2]uint16
+		rand.FillFromBytes(t, &actual, []byte{3, 0, 0, 0, 0, 0, 0, 0, 4})
+		require.Equal(t, [2]uint16{3, 4}, actual)
+	})
+}
+
+func FuzzFromBytes(f *testing.F) {
+
+	f.Add([]byte{})
+	f.Add([]byte("seed corpus entry"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		a := rand.FromBytes[ShrinkStruct](t, data)
+		b := rand.FromBytes[ShrinkStruct](t, data)
+		require.Equal(t, a, b)
+		require.NotEmpty(t, a.Tags)
+	})
+}
@@ -0,0 +1,164 @@
+package rand
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Option configures how values are generated. Every `Option` is also a
+// `CheckOption`, configuring how the inputs of `Check` are generated.
+type Option func(*options)
+
+type options struct {
+	zeroOnly bool
+	overrides map[reflect.Type][]func(reflect.Value)
+	uniqueKeys map[reflect.Type]func(reflect.Value) any
+	generators map[reflect.Type]func(r *rand.Rand) reflect.Value
+	nilPointers float64
+	nilContainers float64
+	emptyContainers float64
+	emptyStrings float64
+	skipTypes map[reflect.Type]bool
+	skipUnexported bool
+	calls *Calls
+	stable bool
+	stableSeed int64
+	source Source
+	// fromBytes is set when decoding from the bytes of `FromBytes`, which run
+	// out, so that maps keep the distinct keys generated before they do.
+	fromBytes bool
+	// tagGenerator generates the strings inside a field tagged with `rand:"<name>"`
+	tagGenerator func(r *rand.Rand) string
+}
+
+func newOptions(t testing.TB, opts []Option) *options {
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for _, p := range []float64{
+		o.nilPointers,
+		o.nilContainers,
+		o.emptyContainers,
+		o.emptyStrings,
+		o.nilContainers + o.emptyContainers,
+	} {
+		if p < 0 || p > 1 {
+			require.Fail(t, "gotest/rand: probabilities must be between 0 and 1", p)
+		}
+	}
+
+	return o
+}
+
+// ZeroOnly leaves values which are already non-zero untouched, recursively,
+// so that only the zero fields of a partially populated value are filled.
+// The unexported fields of a struct which is partly set are never filled, so
+// that values such as a `time.Time` or `decimal.Decimal` are kept as they
+// are. Non-empty maps keep their keys and only have their values filled.
+func ZeroOnly() Option {
+	return func(o *options) {
+		o.zeroOnly = true
+	}
+}
+
+// With calls `override` on every generated value of `Type` once it has been
+// filled, e.g. `rand.New[Order](t, rand.With(func(o *Order) { o.Status = "open" }))`.
+func With[Type any](override func(*Type)) Option {
+	return func(o *options) {
+		if o.overrides == nil {
+			o.overrides = make(map[reflect.Type][]func(reflect.Value))
+		}
+		typ := typeOf[Type]()
+		o.overrides[typ] = append(o.overrides[typ], func(v reflect.Value) {
+			override(v.Interface().(*Type))
+		})
+	}
+}
+
+// UniqueBy makes the elements of every generated slice of `Type` distinct
+// under `key`, e.g. `rand.NewSlice(t, 10, rand.UniqueBy(func(u User) string { return u.Email }))`.
+func UniqueBy[Type any, Key comparable](key func(Type) Key) Option {
+	return func(o *options) {
+		if o.uniqueKeys == nil {
+			o.uniqueKeys = make(map[reflect.Type]func(reflect.Value) any)
+		}
+		typ := typeOf[Type]()
+		o.uniqueKeys[typ] = func(v reflect.Value) any {
+			return key(v.Interface().(Type))
+		}
+	}
+}
+
+// NilPointers sets the probability that a nil pointer is left nil instead of
+// being allocated and filled.
+func NilPointers(p float64) Option {
+	return func(o *options) {
+		o.nilPointers = p
+	}
+}
+
+// NilContainers sets the probability that an empty slice or map is left nil
+// instead of being populated.
+func NilContainers(p float64) Option {
+	return func(o *options) {
+		o.nilContainers = p
+	}
+}
+
+// EmptyContainers sets the probability that an empty slice or map is made
+// empty but non-nil instead of being populated; together with
+// `NilContainers` it must not exceed 1.
+func EmptyContainers(p float64) Option {
+	return func(o *options) {
+		o.emptyContainers = p
+	}
+}
+
+// EmptyStrings sets the probability that a string is generated as "".
+func EmptyStrings(p float64) Option {
+	return func(o *options) {
+		o.emptyStrings = p
+	}
+}
+
+// Skip leaves every value of `Type` untouched, in addition to the types of the
+// `sync` and `sync/atomic` packages and `noCopy` guards which are always
+// left zero.
+func Skip[Type any]() Option {
+	return func(o *options) {
+		if o.skipTypes == nil {
+			o.skipTypes = make(map[reflect.Type]bool)
+		}
+		o.skipTypes[typeOf[Type]()] = true
+	}
+}
+
+// SkipUnexported leaves unexported struct fields untouched; by default they
+// are filled like exported fields. Individual fields can be left untouched
+// with the `rand:"-"` tag.
+func SkipUnexported() Option {
+	return func(o *options) {
+		o.skipUnexported = true
+	}
+}
+
+// Stable derives the value of each struct field from a hash of the seed and
+// the field's path (e.g. ".Customer.Name") rather than from its position in
+// a single random stream. Adding, removing or reordering fields then leaves
+// the values of every other field unchanged, keeping golden files generated
+// with `NewFromSeed` valid as structs evolve.
+//
+// Values generated in this mode are part of this package's compatibility
+// guarantee: a given seed, path and type will keep producing the same value
+// across versions.
+func Stable() Option {
+	return func(o *options) {
+		o.stable = true
+	}
+}
@@ -0,0 +1,228 @@
+package rand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+type Order struct {
+	ID int64
+	Status string
+	Items []OrderItem
+	Meta map[string]string
+	Customer *MyStruct
+}
+
+type OrderItem struct {
+	Name string
+	Quantity int
+}
+
+func TestZeroOnly(t *testing.T) {
+
+	t.Run("non-zero fields are left untouched", func(t *testing.T) {
+		order := Order{
+			Status: "open",
+			Customer: &MyStruct{Exported: "alice"},
+		}
+		rand.FillFromSeed(t, &order, 1234, rand.ZeroOnly())
+
+		require.Equal(t, "open", order.Status)
+		require.Equal(t, "alice", order.Customer.Exported)
+		require.NotZero(t, order.ID)
+		require.NotEmpty(t, order.Items)
+		require.NotEmpty(t, order.Meta)
+		require.Empty(t, order.Customer.unexported)
+	})
+
+	t.Run("unexported fields of a zero struct are filled", func(t *testing.T) {
+		order := Order{Status: "open"}
+		rand.FillFromSeed(t, &order, 1234, rand.ZeroOnly())
+
+		require.NotEmpty(t, order.Customer.unexported)
+	})
+
+	t.Run("decimal and time values which are set are left untouched", func(t *testing.T) {
+		type Payment struct {
+			Amount decimal.Decimal
+			Fee decimal.Decimal
+			At time.Time
+			Settled time.Time
+			Reference string
+		}
+
+		at := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+		payment := Payment{
+			Amount: decimal.New(15, 0),
+			At: at,
+		}
+		rand.FillFromSeed(t, &payment, 1234, rand.ZeroOnly())
+
+		require.Equal(t, "15", payment.Amount.String())
+		require.True(t, payment.Amount.Equal(decimal.New(15, 0)))
+		require.Equal(t, at, payment.At)
+		require.Equal(t, time.UTC, payment.At.Location())
+		require.Equal(t, "2023-04-05T06:07:08Z", payment.At.Format(time.RFC3339))
+		require.NotEmpty(t, payment.Reference)
+	})
+
+	t.Run("non-empty slice elements only have zero fields filled", func(t *testing.T) {
+		order := Order{
+			Items: []OrderItem{{Name: "a"}, {Quantity: 2}},
+		}
+		rand.FillFromSeed(t, &order, 1234, rand.ZeroOnly())
+
+		require.Len(t, order.Items, 2)
+		require.Equal(t, "a", order.Items[0].Name)
+		require.NotZero(t, order.Items[0].Quantity)
+		require.NotEmpty(t, order.Items[1].Name)
+		require.Equal(t, 2, order.Items[1].Quantity)
+	})
+
+	t.Run("non-empty map keeps keys and non-zero values", func(t *testing.T) {
+		order := Order{
+			Meta: map[string]string{"a": "keep", "b": ""},
+		}
+		rand.FillFromSeed(t, &order, 1234, rand.ZeroOnly())
+
+		require.Len(t, order.Meta, 2)
+		require.Equal(t, "keep", order.Meta["a"])
+		require.NotEmpty(t, order.Meta["b"])
+	})
+
+	t.Run("fully populated value is unchanged", func(t *testing.T) {
+		expected := rand.NewFromSeed[Order](t, 1234)
+		actual := expected
+		rand.FillFromSeed(t, &actual, 5678, rand.ZeroOnly())
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("without ZeroOnly every field is filled", func(t *testing.T) {
+		order := Order{Status: "open"}
+		rand.FillFromSeed(t, &order, 1234)
+		require.NotEqual(t, "open", order.Status)
+	})
+}
+
+func TestWith(t *testing.T) {
+
+	t.Run("override top level value", func(t *testing.T) {
+		order := rand.New[Order](t, rand.With(func(o *Order) {
+			o.Status = "open"
+		}))
+		require.Equal(t, "open", order.Status)
+		require.NotZero(t, order.ID)
+	})
+
+	t.Run("override nested values", func(t *testing.T) {
+		order := rand.New[Order](t, rand.With(func(i *OrderItem) {
+			i.Quantity = 1
+		}))
+		require.NotEmpty(t, order.Items)
+		for _, item := range order.Items {
+			require.Equal(t, 1, item.Quantity)
+			require.NotEmpty(t, item.Name)
+		}
+	})
+
+	t.Run("overrides are applied in order", func(t *testing.T) {
+		order := rand.New[Order](t,
+			rand.With(func(o *Order) { o.Status = "open" }),
+			rand.With(func(o *Order) { o.Status += "ed" }),
+		)
+		require.Equal(t, "opened", order.Status)
+	})
+
+	t.Run("override with fill", func(t *testing.T) {
+		var s MyStruct
+		rand.Fill(t, &s, rand.With(func(s *string) { *s = "x" }))
+		require.Equal(t, MyStruct{Exported: "x", unexported: "x"}, s)
+	})
+
+	t.Run("override does not change other values from seed", func(t *testing.T) {
+		expected := rand.NewFromSeed[Order](t, 1234)
+		expected.Status = "open"
+		actual := rand.NewFromSeed[Order](t, 1234, rand.With(func(o *Order) {
+			o.Status = "open"
+		}))
+		require.Equal(t, expected, actual)
+	})
+}
+
+func TestNilAndEmptyProbabilities(t *testing.T) {
+
+	t.Run("always nil", func(t *testing.T) {
+		actual := rand.New[Order](t,
+			rand.NilPointers(1),
+			rand.NilContainers(1),
+			rand.EmptyStrings(1),
+		)
+		require.NotZero(t, actual.ID)
+		require.Equal(t, "", actual.Status)
+		require.Nil(t, actual.Items)
+		require.Nil(t, actual.Meta)
+		require.Nil(t, actual.Customer)
+	})
+
+	t.Run("always empty", func(t *testing.T) {
+		actual := rand.New[Order](t, rand.EmptyContainers(1))
+		require.NotNil(t, actual.Items)
+		require.Empty(t, actual.Items)
+		require.NotNil(t, actual.Meta)
+		require.Empty(t, actual.Meta)
+	})
+
+	t.Run("all outcomes occur", func(t *testing.T) {
+		var nilPtr, ptr, nilSlice, emptySlice, slice, emptyString, str bool
+		for seed := int64(0); seed < 100; seed++ {
+			actual := rand.NewFromSeed[Order](t, seed,
+				rand.NilPointers(0.5),
+				rand.NilContainers(0.3),
+				rand.EmptyContainers(0.3),
+				rand.EmptyStrings(0.5),
+			)
+			nilPtr = nilPtr || actual.Customer == nil
+			ptr = ptr || actual.Customer != nil
+			nilSlice = nilSlice || actual.Items == nil
+			emptySlice = emptySlice || (actual.Items != nil && len(actual.Items) == 0)
+			slice = slice || len(actual.Items) > 0
+			emptyString = emptyString || actual.Status == ""
+			str = str || actual.Status != ""
+		}
+		require.True(t, nilPtr)
+		require.True(t, ptr)
+		require.True(t, nilSlice)
+		require.True(t, emptySlice)
+		require.True(t, slice)
+		require.True(t, emptyString)
+		require.True(t, str)
+	})
+
+	t.Run("probabilities are not applied to explicitly sized slices", func(t *testing.T) {
+		actual := rand.NewSlice[OrderItem](t, 3, rand.NilContainers(1))
+		require.Len(t, actual, 3)
+	})
+
+	t.Run("invalid probability fails", func(t *testing.T) {
+		for _, opt := range []rand.Option{
+			rand.NilPointers(-0.1),
+			rand.EmptyStrings(1.1),
+			rand.NilContainers(2),
+			rand.EmptyContainers(-1),
+		} {
+			opt := opt
+			requireFails(t, func(t *testing.T) {
+				rand.New[Order](t, opt)
+			})
+		}
+
+		requireFails(t, func(t *testing.T) {
+			rand.New[Order](t, rand.NilContainers(0.6), rand.EmptyContainers(0.6))
+		})
+	})
+}
@@ -1,7 +1,9 @@
 package rand
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -16,24 +18,46 @@ var (
 	maxContainerSize = 5
 )
 
-func New[Type any](t testing.TB) Type {
-	return NewFromSeed[Type](t, time.Now().UnixNano())
+const (
+	maxUniqueAttempts = 100
+)
+
+func New[Type any](t testing.TB, opts ...Option) Type {
+	return NewFromSeed[Type](t, time.Now().UnixNano(), opts...)
 }
 
-func NewFromSeed[Type any](t testing.TB, seed int64) Type {
+func NewFromSeed[Type any](t testing.TB, seed int64, opts ...Option) Type {
 	var toFill Type
-	FillFromSeed(t, &toFill, seed)
+	FillFromSeed(t, &toFill, seed, opts...)
 	return toFill
 }
 
-func Fill(t testing.TB, toFill any) {
-	FillFromSeed(t, toFill, time.Now().UnixNano())
+// NewSlice generates a slice of exactly `n` values of `Type`; combine with
+// `UniqueBy` to make the values distinct.
+func NewSlice[Type any](t testing.TB, n int, opts ...Option) []Type {
+	return NewSliceFromSeed[Type](t, n, time.Now().UnixNano(), opts...)
 }
 
-func FillFromSeed(t testing.TB, toFill any, seed int64) {
-	r := rand.New(rand.NewSource(seed))
+func NewSliceFromSeed[Type any](t testing.TB, n int, seed int64, opts ...Option) []Type {
+	if n < 0 {
+		require.Fail(t, "gotest/rand: slice length cannot be less than 0", n)
+	}
+	toFill := make([]Type, 0, n)
+	if n > 0 {
+		FillFromSeed(t, &toFill, seed, opts...)
+	}
+	return toFill
+}
+
+func Fill(t testing.TB, toFill any, opts ...Option) {
+	FillFromSeed(t, toFill, time.Now().UnixNano(), opts...)
+}
+
+func FillFromSeed(t testing.TB, toFill any, seed int64, opts ...Option) {
+	o := newOptions(t, opts)
+	r := newRand(o, seed)
 	v := reflect.ValueOf(toFill)
-	fillValue(t, v, r)
+	fillValue(t, v, r, o, "")
 }
 
 func SetMaxContainerSize(t testing.TB, n int) {
@@ -43,17 +67,46 @@ func SetMaxContainerSize(t testing.TB, n int) {
 	maxContainerSize = n
 }
 
-func fillValue(t testing.TB, v reflect.Value, r *rand.Rand) {
+func fillValue(
+	t testing.TB,
+	v reflect.Value,
+	r *rand.Rand,
+	o *options,
+	path string,
+) {
 
 	if v.Kind() != reflect.Pointer && !v.CanAddr() {
 		require.Fail(t, "gotest/rand: cannot fill unaddressable value - value should be passed by reference")
 	}
 
+	if skipType(o, v.Type()) {
+		return
+	}
+
+	if overrides, ok := o.overrides[v.Type()]; ok && v.CanAddr() {
+		defer func() {
+			for _, override := range overrides {
+				override(v.Addr())
+			}
+		}()
+	}
+
+	if gen, ok := lookupGenerator(o, v.Type()); ok {
+		if v.CanSet() && !(o.zeroOnly && !v.IsZero()) {
+			v.Set(gen(r))
+		}
+		return
+	}
+
+	if o.zeroOnly && isScalar(v.Kind()) && !v.IsZero() {
+		return
+	}
+
 	switch v.Kind() {
 	case reflect.Array:
 		n := v.Len()
 		for i:=0; i<n; i++ {
-			fillValue(t, v.Index(i), r)
+			fillValue(t, v.Index(i), r, o, path + fmt.Sprintf(".[%d]", i))
 		}
 	case reflect.Bool:
 		if v.CanSet() {
@@ -101,41 +154,119 @@ func fillValue(t testing.TB, v reflect.Value, r *rand.Rand) {
 		if v.CanSet() {
 			v.SetInt(r.Int63())
 		}
+	case reflect.Chan:
+		if v.IsNil() && v.CanSet() {
+			n := r.Intn(maxContainerSize - 1) + 1
+			ch := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, v.Type().Elem()), n)
+			// A receive-only channel could never be sent to, so it is
+			// generated with a full buffer
+			if v.Type().ChanDir() == reflect.RecvDir {
+				for i:=0; i<n; i++ {
+					e := reflect.New(v.Type().Elem())
+					fillValue(t, e.Elem(), r, o, path + fmt.Sprintf(".[%d]", i))
+					ch.Send(e.Elem())
+				}
+			}
+			v.Set(ch)
+		}
+	case reflect.Func:
+		if v.IsNil() && v.CanSet() {
+			v.Set(stubFunc(t, v.Type(), r.Int63(), o, path))
+		}
 	case reflect.Interface:
-		if v.CanInterface() {
-			fillValue(t, v.Elem(), r)
+		// The concrete type of a nil interface is unknown, so it is left nil
+		if !v.IsNil() && v.CanSet() {
+			e := reflect.New(v.Elem().Type()).Elem()
+			e.Set(v.Elem())
+			fillValue(t, e, r, o, path)
+			v.Set(e)
 		}
 	case reflect.Map:
+		if o.zeroOnly && v.Len() != 0 {
+			iter := v.MapRange()
+			for iter.Next() {
+				val := reflect.New(v.Type().Elem())
+				val.Elem().Set(iter.Value())
+				fillValue(t, val.Elem(), r, o, path + fmt.Sprintf(".['%v']", iter.Key()))
+				v.SetMapIndex(iter.Key(), val.Elem())
+			}
+			return
+		}
+
+		keyType := v.Type().Key()
+		if keyType.Kind() == reflect.Interface {
+			require.Fail(t, fmt.Sprintf(
+				"gotest/rand: cannot generate keys of %s - interface key types are not supported",
+				v.Type(),
+			))
+			return
+		}
+
 		n := v.Len()
 		if v.Len() == 0 {
+			if leaveContainerAbsent(v, r, o) {
+				return
+			}
 			n = r.Intn(maxContainerSize - 1) + 1
 		}
+		size := n
+		if space, ok := keySpaceSize(keyType); ok && uint64(size) > space {
+			size = int(space)
+		}
 
-		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		v.Set(reflect.MakeMapWithSize(v.Type(), size))
 
-		for i:=0; i<n; i++ {
-			k := reflect.New(v.Type().Key())
-			fillValue(t, k.Elem(), r)
+		// `n` entries are generated whether or not their keys collide, so that
+		// maps without collisions are generated from the same random values as
+		// always; more are then generated until the map has `size` entries
+		misses := 0
+		for i:=0; i<n || v.Len() < size; i++ {
+			k := reflect.New(keyType)
+			fillValue(t, k.Elem(), r, o, path + fmt.Sprintf(".[key %d]", i))
+			if v.MapIndex(k.Elem()).IsValid() {
+				misses++
+			} else {
+				misses = 0
+			}
+			if misses == maxUniqueAttempts && v.Len() < size {
+				if o.fromBytes {
+					// The bytes have run out, so every further key is the same
+					return
+				}
+				require.Fail(t, fmt.Sprintf(
+					"gotest/rand: cannot generate %d distinct map keys of type %s - the key space is too small",
+					size,
+					keyType,
+				))
+				return
+			}
 
 			val := reflect.New(v.Type().Elem())
-			fillValue(t, val.Elem(), r)
+			fillValue(t, val.Elem(), r, o, path + fmt.Sprintf(".['%v']", k.Elem()))
 
 			v.SetMapIndex(k.Elem(), val.Elem())
 		}
 	case reflect.Pointer:
 		if v.IsZero() {
+			if o.nilPointers > 0 && r.Float64() < o.nilPointers {
+				return
+			}
 			v.Set(reflect.New(v.Type().Elem()))
 		}
-		fillValue(t, reflect.Indirect(v), r)
+		fillValue(t, reflect.Indirect(v), r, o, path)
 	case reflect.Slice:
+		seen := make(map[any]bool)
 		if v.Len() != 0 {
 			for i:=0; i<v.Len(); i++ {
-				fillValue(t, v.Index(i), r)
+				fillUniqueValue(t, v.Index(i), r, o, path + fmt.Sprintf(".[%d]", i), seen)
 			}
 			return
 		}
 
 		if v.Cap() == 0 {
+			if leaveContainerAbsent(v, r, o) {
+				return
+			}
 			n := r.Intn(maxContainerSize - 1) + 1
 			v.Grow(n)
 			// Grow _may_ set the capasicty to something larger than n;
@@ -145,23 +276,68 @@ func fillValue(t testing.TB, v reflect.Value, r *rand.Rand) {
 
 		for i:=0; i<v.Cap(); i++ {
 			e := reflect.New(v.Type().Elem())
-			fillValue(t, e, r)
+			fillUniqueValue(t, e.Elem(), r, o, path + fmt.Sprintf(".[%d]", i), seen)
 			v.Set(reflect.Append(v, reflect.Indirect(e)))
 		}
 	case reflect.String:
 		if v.CanSet() {
-			v.SetString(fmt.Sprintf("%x", r.Uint64()))
+			if o.emptyStrings > 0 && r.Float64() < o.emptyStrings {
+				v.SetString("")
+			} else if o.tagGenerator != nil {
+				v.SetString(o.tagGenerator(r))
+			} else {
+				v.SetString(fmt.Sprintf("%x", r.Uint64()))
+			}
 		}
 	case reflect.Struct:
+		// The unexported fields of a struct which is already partly set, such
+		// as a `time.Time` or a `decimal.Decimal`, are only valid as they are
+		partlySet := o.zeroOnly && !v.IsZero()
 		n := v.NumField()
 		for i:=0; i<n; i++ {
+			field := v.Type().Field(i)
+			if (o.skipUnexported || partlySet) && !field.IsExported() {
+				continue
+			}
+
 			f := v.Field(i)
-			if f.CanSet() {
-				fillValue(t, f, r)
-			} else {
-				newF := reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr()))
-				fillValue(t, newF.Elem(), r)
+			if !f.CanSet() {
+				f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
 			}
+
+			fieldOpts := o
+			if tag, ok := field.Tag.Lookup(tagName); ok {
+				if tag == "-" {
+					continue
+				}
+
+				gen, ok := lookupTag(tag)
+				if !ok {
+					require.Fail(t, fmt.Sprintf(
+						"gotest/rand: unknown generator tag `%s:\"%s\"` on field %s.%s",
+						tagName,
+						tag,
+						v.Type(),
+						field.Name,
+					))
+					return
+				}
+				tagged := *o
+				tagged.tagGenerator = gen
+				fieldOpts = &tagged
+			} else if o.tagGenerator != nil {
+				untagged := *o
+				untagged.tagGenerator = nil
+				fieldOpts = &untagged
+			}
+
+			fieldPath := path + "." + field.Name
+			fieldR := r
+			if o.stable {
+				fieldR = rand.New(rand.NewSource(pathSeed(o.stableSeed, fieldPath)))
+			}
+
+			fillValue(t, f, fieldR, fieldOpts, fieldPath)
 		}
 	case reflect.Uint:
 		if v.CanSet() {
@@ -189,3 +365,162 @@ func fillValue(t testing.TB, v reflect.Value, r *rand.Rand) {
 		}
 	}
 }
+
+func isScalar(k reflect.Kind) bool {
+
+	switch k {
+	case reflect.Bool,
+		reflect.Complex64, reflect.Complex128,
+		reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.String,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// pathSeed derives the seed of the value at `path` in `Stable` mode.
+func pathSeed(seed int64, path string) int64 {
+
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, seed)
+	h.Write([]byte(path))
+	return int64(h.Sum64())
+}
+
+// retryPath distinguishes the path of each attempt at generating a unique
+// value, so that `Stable` mode does not regenerate the same value.
+func retryPath(path string, attempt int) string {
+
+	if attempt == 1 {
+		return path
+	}
+	return fmt.Sprintf("%s#%d", path, attempt)
+}
+
+// skipType reports whether values of `typ` must be left untouched: types
+// added with `Skip`, the types of the `sync` and `sync/atomic` packages and
+// `noCopy` guards, none of which are valid unless zero.
+func skipType(o *options, typ reflect.Type) bool {
+
+	if o.skipTypes[typ] {
+		return true
+	}
+
+	switch typ.PkgPath() {
+	case "sync", "sync/atomic":
+		return true
+	}
+	return typ.Name() == "noCopy"
+}
+
+// leaveContainerAbsent sets the slice or map `v` to nil or empty, according
+// to the `NilContainers` and `EmptyContainers` probabilities, and reports
+// whether it did so.
+func leaveContainerAbsent(v reflect.Value, r *rand.Rand, o *options) bool {
+
+	if o.nilContainers == 0 && o.emptyContainers == 0 {
+		return false
+	}
+
+	p := r.Float64()
+	switch {
+	case p < o.nilContainers:
+		v.Set(reflect.Zero(v.Type()))
+	case p < o.nilContainers + o.emptyContainers:
+		if v.Kind() == reflect.Map {
+			v.Set(reflect.MakeMap(v.Type()))
+		} else {
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// fillUniqueValue fills `v` as `fillValue` does, regenerating it until its key
+// under any `UniqueBy` registered for its type is not already in `seen`.
+func fillUniqueValue(
+	t testing.TB,
+	v reflect.Value,
+	r *rand.Rand,
+	o *options,
+	path string,
+	seen map[any]bool,
+) {
+
+	uniqueKey, ok := o.uniqueKeys[v.Type()]
+	if !ok {
+		fillValue(t, v, r, o, path)
+		return
+	}
+
+	orig := reflect.New(v.Type()).Elem()
+	orig.Set(v)
+
+	for attempt := 1; ; attempt++ {
+		fillValue(t, v, r, o, retryPath(path, attempt))
+		key := uniqueKey(v)
+		if !seen[key] {
+			seen[key] = true
+			return
+		}
+		if attempt == maxUniqueAttempts {
+			require.Fail(t, fmt.Sprintf(
+				"gotest/rand: cannot generate a %s with a unique key after %d attempts; key %#v was already generated",
+				v.Type(),
+				attempt,
+				key,
+			))
+			return
+		}
+		v.Set(orig)
+	}
+}
+
+// keySpaceSize returns the number of distinct values of `typ` when that
+// number is small enough to be exhausted by generated map keys.
+func keySpaceSize(typ reflect.Type) (uint64, bool) {
+
+	const limit = 1 << 32
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return 2, true
+	case reflect.Int8, reflect.Uint8:
+		return 1 << 8, true
+	case reflect.Int16, reflect.Uint16:
+		return 1 << 16, true
+	case reflect.Array:
+		elemSize, ok := keySpaceSize(typ.Elem())
+		if !ok {
+			return 0, false
+		}
+		size := uint64(1)
+		for i:=0; i<typ.Len(); i++ {
+			size *= elemSize
+			if size > limit {
+				return 0, false
+			}
+		}
+		return size, true
+	case reflect.Struct:
+		size := uint64(1)
+		for i:=0; i<typ.NumField(); i++ {
+			fieldSize, ok := keySpaceSize(typ.Field(i).Type)
+			if !ok {
+				return 0, false
+			}
+			size *= fieldSize
+			if size > limit {
+				return 0, false
+			}
+		}
+		return size, true
+	default:
+		return 0, false
+	}
+}
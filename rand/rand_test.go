@@ -3,6 +3,10 @@ package rand_test
 import (
 	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -210,6 +214,101 @@ func TestMaps(t *testing.T) {
 		require.Equal(t, expected, toFill)
 	})
 
+	t.Run("map with small key space is not larger than key space", func(t *testing.T) {
+		for seed := int64(0); seed < 50; seed++ {
+			toFill := rand.NewFromSeed[map[struct{}]int](t, seed)
+			require.Len(t, toFill, 1)
+		}
+	})
+
+	t.Run("map with existing keys from small key space keeps its size", func(t *testing.T) {
+		for seed := int64(0); seed < 50; seed++ {
+			toFill := map[[2]bool]int{
+				{false, false}: 0,
+				{false, true}: 0,
+				{true, false}: 0,
+				{true, true}: 0,
+			}
+			rand.FillFromSeed(t, &toFill, seed)
+			require.Len(t, toFill, 4)
+		}
+	})
+
+	t.Run("map with interface keys is not supported", func(t *testing.T) {
+		msg := requireFailsWith(t, func(t testing.TB) {
+			rand.NewFromSeed[map[any]string](t, 1234)
+		})
+		require.Contains(t, msg, "cannot generate keys of map[interface {}]string - interface key types are not supported")
+
+		msg = requireFailsWith(t, func(t testing.TB) {
+			rand.NewFromSeed[map[error]int](t, 1234)
+		})
+		require.Contains(t, msg, "cannot generate keys of map[error]int - interface key types are not supported")
+	})
+
+	t.Run("map with existing keys keeps its size", func(t *testing.T) {
+		toFill := map[int8]string{}
+		for i:=0; i<200; i++ {
+			toFill[int8(i)] = ""
+		}
+		rand.FillFromSeed(t, &toFill, 7238)
+		require.Len(t, toFill, 200)
+	})
+}
+
+func TestNewSlice(t *testing.T) {
+
+	t.Run("generates exactly n values", func(t *testing.T) {
+		for _, n := range []int{0, 1, 7, 50} {
+			actual := rand.NewSlice[MyStruct](t, n)
+			require.Len(t, actual, n)
+			for _, s := range actual {
+				require.NotEmpty(t, s.Exported)
+			}
+		}
+	})
+
+	t.Run("from seed is deterministic", func(t *testing.T) {
+		a := rand.NewSliceFromSeed[MyStruct](t, 3, 1234)
+		b := rand.NewSliceFromSeed[MyStruct](t, 3, 1234)
+		require.Equal(t, a, b)
+	})
+
+	t.Run("unique by key", func(t *testing.T) {
+		actual := rand.NewSliceFromSeed[OrderItem](t, 50, 1234,
+			rand.With(func(i *OrderItem) { i.Quantity %= 60 }),
+			rand.UniqueBy(func(i OrderItem) int { return i.Quantity }),
+		)
+		require.Len(t, actual, 50)
+
+		seen := make(map[int]bool)
+		for _, item := range actual {
+			require.False(t, seen[item.Quantity], item.Quantity)
+			seen[item.Quantity] = true
+		}
+	})
+
+	t.Run("unique by fails when keys cannot be unique", func(t *testing.T) {
+		requireFails(t, func(t *testing.T) {
+			rand.NewSliceFromSeed[OrderItem](t, 2, 1234,
+				rand.UniqueBy(func(i OrderItem) bool { return true }),
+			)
+		})
+	})
+
+	t.Run("unique by applies to nested slices", func(t *testing.T) {
+		for seed := int64(0); seed < 20; seed++ {
+			order := rand.NewFromSeed[Order](t, seed,
+				rand.With(func(i *OrderItem) { i.Quantity %= 6 }),
+				rand.UniqueBy(func(i OrderItem) int { return i.Quantity }),
+			)
+			seen := make(map[int]bool)
+			for _, item := range order.Items {
+				require.False(t, seen[item.Quantity], item.Quantity)
+				seen[item.Quantity] = true
+			}
+		}
+	})
 }
 
 func TestStructs(t *testing.T) {
@@ -237,6 +336,104 @@ func TestStructs(t *testing.T) {
 	)
 }
 
+type WithSync struct {
+	Name string
+	Mu sync.Mutex
+	RW *sync.RWMutex
+	Once sync.Once
+	Count atomic.Int64
+	Ptr atomic.Pointer[MyStruct]
+	guard noCopy
+	Err error
+	Any any
+	Skipped string `rand:"-"`
+}
+
+type noCopy struct {
+	n int
+}
+
+func TestSkippedTypes(t *testing.T) {
+
+	t.Run("sync types and noCopy are left zero", func(t *testing.T) {
+		actual := rand.New[WithSync](t)
+		require.NotEmpty(t, actual.Name)
+		require.NotNil(t, actual.RW)
+		actual.RW.Lock()
+		actual.RW.Unlock()
+		require.Equal(t, int64(0), actual.Count.Load())
+		require.Nil(t, actual.Ptr.Load())
+		require.Equal(t, noCopy{}, actual.guard)
+		require.Empty(t, actual.Skipped)
+
+		actual.Mu.Lock()
+		actual.Mu.Unlock()
+		var called bool
+		actual.Once.Do(func() { called = true })
+		require.True(t, called)
+	})
+
+	t.Run("nil interfaces are left nil", func(t *testing.T) {
+		actual := rand.New[WithSync](t)
+		require.Nil(t, actual.Err)
+		require.Nil(t, actual.Any)
+	})
+
+	t.Run("non-nil interfaces are filled", func(t *testing.T) {
+		actual := WithSync{Any: MyStruct{}}
+		rand.Fill(t, &actual)
+		require.NotEmpty(t, actual.Any.(MyStruct).Exported)
+	})
+
+	t.Run("skip type", func(t *testing.T) {
+		actual := rand.New[MyNestedStruct](t, rand.Skip[MyStruct]())
+		require.NotEmpty(t, actual.Exported)
+		require.Equal(t, MyStruct{}, actual.ExpNest)
+		require.Equal(t, MyStruct{}, actual.unexpNest)
+	})
+
+	t.Run("skip unexported", func(t *testing.T) {
+		actual := rand.New[MyNestedStruct](t, rand.SkipUnexported())
+		require.NotEmpty(t, actual.Exported)
+		require.NotEmpty(t, actual.ExpNest.Exported)
+		require.Empty(t, actual.unexported)
+		require.Empty(t, actual.ExpNest.unexported)
+		require.Equal(t, MyStruct{}, actual.unexpNest)
+	})
+}
+
+func TestChannels(t *testing.T) {
+
+	t.Run("bidirectional channel is buffered and empty", func(t *testing.T) {
+		actual := rand.New[chan MyStruct](t)
+		require.NotNil(t, actual)
+		require.Equal(t, 0, len(actual))
+		require.Greater(t, cap(actual), 0)
+		actual <- MyStruct{}
+	})
+
+	t.Run("receive only channel is filled", func(t *testing.T) {
+		actual := rand.New[struct {
+			Ch <-chan string
+		}](t)
+		require.Greater(t, len(actual.Ch), 0)
+		require.Equal(t, len(actual.Ch), cap(actual.Ch))
+		require.NotEmpty(t, <-actual.Ch)
+	})
+
+	t.Run("send only channel", func(t *testing.T) {
+		actual := rand.New[chan<- int](t)
+		actual <- 1
+	})
+
+	t.Run("existing channel is not replaced", func(t *testing.T) {
+		ch := make(chan int)
+		actual := ch
+		rand.Fill(t, &actual)
+		require.Equal(t, ch, actual)
+	})
+}
+
 func testFromSeedWithExpected[F any](t *testing.T, seed int64, expected F) {
 	testName := fmt.Sprintf("%s_%d", reflect.TypeOf(expected).String(), seed)
 	t.Run("FillFromSeed_" + testName, func(t *testing.T) {
@@ -260,3 +457,63 @@ func testFillFromSeedLogicallyEqual[F any](t *testing.T, seed int64, expected F)
 		assert.LogicallyEqual(t, expected, toFill)
 	})
 }
+
+func requireFails(t *testing.T, f func(t *testing.T)) {
+
+	fakeT := &testing.T{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(fakeT)
+	}()
+	<-done
+	require.True(t, fakeT.Failed())
+}
+
+// recordingTB records the failures reported by generators.
+type recordingTB struct {
+	testing.TB
+	errors []string
+	cleanups []func()
+}
+
+func (t *recordingTB) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+// finish runs the cleanups registered with the test, as when it finishes.
+func (t *recordingTB) finish() {
+
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func (t *recordingTB) Helper() {
+}
+
+func (t *recordingTB) Name() string {
+	return "recordingTB"
+}
+
+func (t *recordingTB) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingTB) FailNow() {
+	runtime.Goexit()
+}
+
+// requireFailsWith requires `f` to fail `t` and returns its failure messages.
+func requireFailsWith(t *testing.T, f func(t testing.TB)) string {
+
+	rec := &recordingTB{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(rec)
+	}()
+	<-done
+	require.NotEmpty(t, rec.errors)
+	return strings.Join(rec.errors, "\n")
+}
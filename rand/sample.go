@@ -0,0 +1,224 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Bound is satisfied by the types which `Between` can generate values of.
+type Bound interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+	~float32 | ~float64 |
+	time.Time
+}
+
+// Choice is a value which `Weighted` picks with a probability proportional to
+// its weight.
+type Choice[Type any] struct {
+	Weight float64
+	Value Type
+}
+
+func OneOf[Type any](t testing.TB, from ...Type) Type {
+	return OneOfFromSeed(t, time.Now().UnixNano(), from...)
+}
+
+func OneOfFromSeed[Type any](t testing.TB, seed int64, from ...Type) Type {
+
+	if len(from) == 0 {
+		require.Fail(t, "gotest/rand: cannot pick one of no values")
+	}
+	r := rand.New(rand.NewSource(seed))
+	return from[r.Intn(len(from))]
+}
+
+// Between returns a value in the range [lo, hi] for integers and times, and
+// in [lo, hi) for floats.
+func Between[Type Bound](t testing.TB, lo, hi Type) Type {
+	return BetweenFromSeed(t, time.Now().UnixNano(), lo, hi)
+}
+
+func BetweenFromSeed[Type Bound](t testing.TB, seed int64, lo, hi Type) Type {
+
+	r := rand.New(rand.NewSource(seed))
+
+	if l, ok := any(lo).(time.Time); ok {
+		h := any(hi).(time.Time)
+		if h.Before(l) {
+			require.Fail(t, fmt.Sprintf("gotest/rand: lower bound %v is after upper bound %v", l, h))
+		}
+		return any(timeBetween(r, l, h)).(Type)
+	}
+
+	l := reflect.ValueOf(lo)
+	h := reflect.ValueOf(hi)
+	res := reflect.New(l.Type()).Elem()
+
+	switch l.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if h.Int() < l.Int() {
+			require.Fail(t, fmt.Sprintf("gotest/rand: lower bound %v is greater than upper bound %v", lo, hi))
+		}
+		span := uint64(h.Int() - l.Int())
+		res.SetInt(l.Int() + int64(uint64n(r, span + 1)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if h.Uint() < l.Uint() {
+			require.Fail(t, fmt.Sprintf("gotest/rand: lower bound %v is greater than upper bound %v", lo, hi))
+		}
+		span := h.Uint() - l.Uint()
+		res.SetUint(l.Uint() + uint64n(r, span + 1))
+	case reflect.Float32, reflect.Float64:
+		if h.Float() < l.Float() {
+			require.Fail(t, fmt.Sprintf("gotest/rand: lower bound %v is greater than upper bound %v", lo, hi))
+		}
+		lf, hf := l.Float(), h.Float()
+		if lf == hf {
+			res.SetFloat(lf)
+			break
+		}
+		// Interpolating between the bounds rather than scaling `hf - lf`, which
+		// overflows for ranges wider than the largest float
+		p := r.Float64()
+		res.SetFloat(lf*(1 - p) + hf*p)
+		// Rounding, including to float32, may land outside [lo, hi)
+		if res.Float() < lf {
+			res.SetFloat(lf)
+		}
+		if res.Float() >= hf {
+			if l.Kind() == reflect.Float32 {
+				res.SetFloat(float64(math.Nextafter32(float32(hf), float32(lf))))
+			} else {
+				res.SetFloat(math.Nextafter(hf, lf))
+			}
+		}
+	}
+
+	return res.Interface().(Type)
+}
+
+// Shuffle returns a shuffled copy of `from`.
+func Shuffle[Type any](t testing.TB, from []Type) []Type {
+	return ShuffleFromSeed(t, time.Now().UnixNano(), from)
+}
+
+func ShuffleFromSeed[Type any](t testing.TB, seed int64, from []Type) []Type {
+
+	r := rand.New(rand.NewSource(seed))
+	shuffled := append([]Type(nil), from...)
+	r.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// Subset returns a random subset of `from`, keeping the order of `from`; each
+// value is included with a probability of one half.
+func Subset[Type any](t testing.TB, from []Type) []Type {
+	return SubsetFromSeed(t, time.Now().UnixNano(), from)
+}
+
+func SubsetFromSeed[Type any](t testing.TB, seed int64, from []Type) []Type {
+
+	r := rand.New(rand.NewSource(seed))
+	subset := make([]Type, 0, len(from))
+	for _, v := range from {
+		if r.Int()%2 == 0 {
+			subset = append(subset, v)
+		}
+	}
+	return subset
+}
+
+// Weighted picks the value of one of `choices` with a probability
+// proportional to its weight.
+func Weighted[Type any](t testing.TB, choices ...Choice[Type]) Type {
+	return WeightedFromSeed(t, time.Now().UnixNano(), choices...)
+}
+
+func WeightedFromSeed[Type any](t testing.TB, seed int64, choices ...Choice[Type]) Type {
+
+	var total float64
+	for _, c := range choices {
+		if c.Weight < 0 {
+			require.Fail(t, "gotest/rand: weights cannot be less than 0", c.Weight)
+		}
+		total += c.Weight
+	}
+	if total <= 0 {
+		require.Fail(t, "gotest/rand: weights must add up to more than 0")
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	p := r.Float64() * total
+	for _, c := range choices {
+		if p < c.Weight {
+			return c.Value
+		}
+		p -= c.Weight
+	}
+	// Only reachable through floating point rounding
+	for i := len(choices) - 1; ; i-- {
+		if choices[i].Weight > 0 {
+			return choices[i].Value
+		}
+	}
+}
+
+// timeBetween returns a time in [lo, hi], in the location of `lo`. The span is
+// measured in seconds and nanoseconds rather than as a `time.Duration`, which
+// cannot hold spans of more than about 292 years.
+func timeBetween(r *rand.Rand, lo, hi time.Time) time.Time {
+
+	secs := uint64(hi.Unix()) - uint64(lo.Unix())
+	nanos := int64(hi.Nanosecond()) - int64(lo.Nanosecond())
+
+	var v time.Time
+	if secs < math.MaxUint64/uint64(time.Second) - 1 {
+		span := secs*uint64(time.Second)
+		if nanos >= 0 {
+			span += uint64(nanos)
+		} else {
+			span -= uint64(-nanos)
+		}
+		offset := uint64n(r, span + 1)
+		v = time.Unix(
+			lo.Unix() + int64(offset/uint64(time.Second)),
+			int64(lo.Nanosecond()) + int64(offset%uint64(time.Second)),
+		)
+	} else {
+		v = time.Unix(
+			lo.Unix() + int64(uint64n(r, secs + 1)),
+			r.Int63n(int64(time.Second)),
+		)
+		if v.Before(lo) {
+			v = lo
+		}
+		if v.After(hi) {
+			v = hi
+		}
+	}
+	return v.In(lo.Location())
+}
+
+// uint64n returns a uniformly distributed value in [0, n); when n is 0 the
+// value is in the full uint64 range.
+func uint64n(r *rand.Rand, n uint64) uint64 {
+
+	if n == 0 {
+		return r.Uint64()
+	}
+	threshold := (math.MaxUint64 - n + 1) % n
+	for {
+		v := r.Uint64()
+		if v >= threshold {
+			return v % n
+		}
+	}
+}
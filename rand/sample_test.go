@@ -0,0 +1,212 @@
+package rand_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+type Level int
+
+func TestOneOf(t *testing.T) {
+
+	from := []string{"a", "b", "c"}
+	seen := make(map[string]bool)
+	for seed := int64(0); seed < 50; seed++ {
+		v := rand.OneOfFromSeed(t, seed, from...)
+		require.Contains(t, from, v)
+		seen[v] = true
+	}
+	require.Len(t, seen, 3)
+
+	require.Contains(t, from, rand.OneOf(t, from...))
+	require.Equal(t, rand.OneOfFromSeed(t, 1234, from...), rand.OneOfFromSeed(t, 1234, from...))
+
+	requireFails(t, func(t *testing.T) {
+		rand.OneOf[int](t)
+	})
+}
+
+func TestBetween(t *testing.T) {
+
+	t.Run("ints are inclusive", func(t *testing.T) {
+		seen := make(map[int]bool)
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, -2, 2)
+			require.GreaterOrEqual(t, v, -2)
+			require.LessOrEqual(t, v, 2)
+			seen[v] = true
+		}
+		require.Len(t, seen, 5)
+	})
+
+	t.Run("named int types", func(t *testing.T) {
+		v := rand.Between(t, Level(3), Level(5))
+		require.True(t, v >= 3 && v <= 5, v)
+	})
+
+	t.Run("equal bounds", func(t *testing.T) {
+		require.Equal(t, uint8(7), rand.Between(t, uint8(7), uint8(7)))
+	})
+
+	t.Run("full ranges", func(t *testing.T) {
+		rand.Between(t, int64(math.MinInt64), int64(math.MaxInt64))
+		rand.Between(t, uint64(0), uint64(math.MaxUint64))
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, 1.5, 2.5)
+			require.GreaterOrEqual(t, v, 1.5)
+			require.Less(t, v, 2.5)
+		}
+	})
+
+	t.Run("durations", func(t *testing.T) {
+		v := rand.Between(t, time.Second, time.Minute)
+		require.True(t, v >= time.Second && v <= time.Minute, v)
+	})
+
+	t.Run("times", func(t *testing.T) {
+		lo := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		hi := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, lo, hi)
+			require.False(t, v.Before(lo))
+			require.False(t, v.After(hi))
+		}
+	})
+
+	t.Run("floats over the full range", func(t *testing.T) {
+		var negative, positive bool
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, -math.MaxFloat64, math.MaxFloat64)
+			require.False(t, math.IsInf(v, 0) || math.IsNaN(v), v)
+			require.Less(t, v, math.MaxFloat64)
+			negative = negative || v < 0
+			positive = positive || v > 0
+		}
+		require.True(t, negative)
+		require.True(t, positive)
+
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, float32(-math.MaxFloat32), float32(math.MaxFloat32))
+			require.False(t, math.IsInf(float64(v), 0) || math.IsNaN(float64(v)), v)
+			require.Less(t, v, float32(math.MaxFloat32))
+		}
+	})
+
+	t.Run("floats in the smallest range", func(t *testing.T) {
+		lo := 1.0
+		hi := math.Nextafter(lo, 2)
+		for seed := int64(0); seed < 100; seed++ {
+			require.Equal(t, lo, rand.BetweenFromSeed(t, seed, lo, hi))
+		}
+		require.Equal(t, lo, rand.Between(t, lo, lo))
+	})
+
+	t.Run("times further apart than the longest duration", func(t *testing.T) {
+		lo := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+		hi := time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)
+		years := make(map[int]bool)
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, lo, hi)
+			require.False(t, v.Before(lo), v)
+			require.False(t, v.After(hi), v)
+			require.Equal(t, time.UTC, v.Location())
+			years[v.Year()/1000] = true
+		}
+		require.Greater(t, len(years), 5)
+	})
+
+	t.Run("times a few centuries apart", func(t *testing.T) {
+		lo := time.Date(1800, 1, 1, 0, 0, 0, 500, time.UTC)
+		hi := time.Date(2300, 1, 1, 0, 0, 0, 100, time.UTC)
+		centuries := make(map[int]bool)
+		for seed := int64(0); seed < 100; seed++ {
+			v := rand.BetweenFromSeed(t, seed, lo, hi)
+			require.False(t, v.Before(lo), v)
+			require.False(t, v.After(hi), v)
+			centuries[v.Year()/100] = true
+		}
+		require.Greater(t, len(centuries), 3)
+	})
+
+	t.Run("lower bound greater than upper bound fails", func(t *testing.T) {
+		requireFails(t, func(t *testing.T) {
+			rand.Between(t, 2, 1)
+		})
+		requireFails(t, func(t *testing.T) {
+			rand.Between(t, uint(2), uint(1))
+		})
+		requireFails(t, func(t *testing.T) {
+			rand.Between(t, 2.0, 1.0)
+		})
+		requireFails(t, func(t *testing.T) {
+			rand.Between(t, time.Now(), time.Now().Add(-time.Hour))
+		})
+	})
+}
+
+func TestShuffle(t *testing.T) {
+
+	from := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	actual := rand.ShuffleFromSeed(t, 1234, from)
+	require.ElementsMatch(t, from, actual)
+	require.NotEqual(t, from, actual)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, from)
+	require.Equal(t, actual, rand.ShuffleFromSeed(t, 1234, from))
+
+	require.ElementsMatch(t, from, rand.Shuffle(t, from))
+	require.Empty(t, rand.Shuffle[int](t, nil))
+}
+
+func TestSubset(t *testing.T) {
+
+	from := []string{"a", "b", "c", "d", "e", "f"}
+	lengths := make(map[int]bool)
+	for seed := int64(0); seed < 100; seed++ {
+		actual := rand.SubsetFromSeed(t, seed, from)
+		lengths[len(actual)] = true
+
+		i := 0
+		for _, v := range actual {
+			for from[i] != v {
+				i++
+			}
+		}
+	}
+	require.True(t, lengths[0] || lengths[1])
+	require.True(t, lengths[5] || lengths[6])
+
+	require.LessOrEqual(t, len(rand.Subset(t, from)), len(from))
+}
+
+func TestWeighted(t *testing.T) {
+
+	counts := make(map[string]int)
+	for seed := int64(0); seed < 1000; seed++ {
+		v := rand.WeightedFromSeed(t, seed,
+			rand.Choice[string]{Weight: 9, Value: "common"},
+			rand.Choice[string]{Weight: 1, Value: "rare"},
+			rand.Choice[string]{Weight: 0, Value: "never"},
+		)
+		counts[v]++
+	}
+	require.Zero(t, counts["never"])
+	require.Greater(t, counts["common"], 800)
+	require.Greater(t, counts["rare"], 50)
+
+	require.Equal(t, "only", rand.Weighted(t, rand.Choice[string]{Weight: 0.5, Value: "only"}))
+
+	requireFails(t, func(t *testing.T) {
+		rand.Weighted[string](t)
+	})
+	requireFails(t, func(t *testing.T) {
+		rand.Weighted(t, rand.Choice[int]{Weight: -1, Value: 1}, rand.Choice[int]{Weight: 2, Value: 2})
+	})
+}
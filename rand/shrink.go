@@ -0,0 +1,336 @@
+package rand
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+const (
+	maxShrinkAttempts = 10000
+)
+
+// Shrink repeatedly simplifies `in` - zeroing fields, shortening slices,
+// maps and strings and moving numbers towards zero - for as long as `fails`
+// still reports the simplified value as failing, and returns the minimal
+// failing value it finds.
+func Shrink[Type any](in Type, fails func(Type) bool) Type {
+
+	cur := reflect.New(reflect.TypeOf(&in).Elem()).Elem()
+	cur.Set(reflect.ValueOf(&in).Elem())
+	home := homePackage(cur.Type())
+
+	attempts := 0
+	for attempts < maxShrinkAttempts {
+		var next reflect.Value
+		shrinkValue(cur, home, func(c reflect.Value) bool {
+			attempts++
+			if fails(c.Interface().(Type)) {
+				next = c
+				return true
+			}
+			return attempts >= maxShrinkAttempts
+		})
+
+		if !next.IsValid() {
+			break
+		}
+		cur = next
+	}
+
+	return cur.Interface().(Type)
+}
+
+// FormatPaths renders `v` as one `path: value` line per leaf value, using the
+// same path notation as the failure messages of `assert.LogicallyEqual`.
+func FormatPaths(v any) string {
+
+	var lines []string
+	if v == nil {
+		return ".: nil"
+	}
+	rv := reflect.New(reflect.TypeOf(v)).Elem()
+	rv.Set(reflect.ValueOf(v))
+	formatPaths(rv, "", &lines)
+	return strings.Join(lines, "\n")
+}
+
+// NoShrink disables shrinking of failing inputs in `Check`.
+func NoShrink() CheckOption {
+	return checkOption(func(o *checkOptions) {
+		o.noShrink = true
+	})
+}
+
+// shrinkValue calls `yield` with simpler candidates for `v`, stopping as soon
+// as `yield` returns true. Candidates never share mutable state with `v`.
+//
+// Unexported fields are only shrunk in structs of the package `home`; those
+// of other packages, such as of a `decimal.Decimal`, are only valid as they
+// are.
+func shrinkValue(v reflect.Value, home string, yield func(reflect.Value) bool) bool {
+
+	if !v.IsZero() {
+		if yield(reflect.New(v.Type()).Elem()) {
+			return true
+		}
+	}
+
+	with := func(set func(c reflect.Value)) reflect.Value {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		set(c)
+		return c
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		for d := n/2; d != 0; d /= 2 {
+			if yield(with(func(c reflect.Value) { c.SetInt(n - d) })) {
+				return true
+			}
+		}
+		if n > 0 {
+			return yield(with(func(c reflect.Value) { c.SetInt(n - 1) }))
+		} else if n < 0 {
+			return yield(with(func(c reflect.Value) { c.SetInt(n + 1) }))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		for d := n/2; d != 0; d /= 2 {
+			if yield(with(func(c reflect.Value) { c.SetUint(n - d) })) {
+				return true
+			}
+		}
+		if n > 0 {
+			return yield(with(func(c reflect.Value) { c.SetUint(n - 1) }))
+		}
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if float64(int64(f)) != f {
+			if yield(with(func(c reflect.Value) { c.SetFloat(float64(int64(f))) })) {
+				return true
+			}
+		}
+		if f/2 != f {
+			return yield(with(func(c reflect.Value) { c.SetFloat(f/2) }))
+		}
+	case reflect.String:
+		s := v.String()
+		if len(s) > 1 {
+			for _, cand := range []string{s[:len(s)/2], s[len(s)/2:], s[1:], s[:len(s)-1]} {
+				cand := cand
+				if yield(with(func(c reflect.Value) { c.SetString(cand) })) {
+					return true
+				}
+			}
+		}
+	case reflect.Array:
+		for i:=0; i<v.Len(); i++ {
+			i := i
+			stop := shrinkValue(v.Index(i), home, func(e reflect.Value) bool {
+				return yield(with(func(c reflect.Value) { c.Index(i).Set(e) }))
+			})
+			if stop {
+				return true
+			}
+		}
+	case reflect.Slice:
+		n := v.Len()
+		sub := func(from, to int) reflect.Value {
+			c := reflect.MakeSlice(v.Type(), 0, n)
+			c = reflect.AppendSlice(c, v.Slice(0, from))
+			return reflect.AppendSlice(c, v.Slice(to, n))
+		}
+		if n > 1 {
+			if yield(sub(n/2, n)) || yield(sub(0, n/2)) {
+				return true
+			}
+		}
+		for i:=0; i<n; i++ {
+			if yield(sub(i, i+1)) {
+				return true
+			}
+		}
+		for i:=0; i<n; i++ {
+			i := i
+			stop := shrinkValue(v.Index(i), home, func(e reflect.Value) bool {
+				c := sub(n, n)
+				c.Index(i).Set(e)
+				return yield(c)
+			})
+			if stop {
+				return true
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		copyMap := func(without reflect.Value) reflect.Value {
+			c := reflect.MakeMapWithSize(v.Type(), v.Len())
+			for _, k := range keys {
+				if without.IsValid() && k.Equal(without) {
+					continue
+				}
+				c.SetMapIndex(k, v.MapIndex(k))
+			}
+			return c
+		}
+		for _, k := range keys {
+			if yield(copyMap(k)) {
+				return true
+			}
+		}
+		for _, k := range keys {
+			k := k
+			// Map elements are not addressable, so are shrunk from a copy
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(k))
+			stop := shrinkValue(elem, home, func(e reflect.Value) bool {
+				c := copyMap(reflect.Value{})
+				c.SetMapIndex(k, e)
+				return yield(c)
+			})
+			if stop {
+				return true
+			}
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return shrinkValue(v.Elem(), home, func(e reflect.Value) bool {
+				p := reflect.New(v.Type().Elem())
+				p.Elem().Set(e)
+				return yield(p)
+			})
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			// The value in an interface is not addressable either
+			elem := reflect.New(v.Elem().Type()).Elem()
+			elem.Set(v.Elem())
+			return shrinkValue(elem, home, func(e reflect.Value) bool {
+				return yield(with(func(c reflect.Value) { c.Set(e) }))
+			})
+		}
+	case reflect.Struct:
+		for i:=0; i<v.NumField(); i++ {
+			i := i
+			field := v.Type().Field(i)
+			if !field.IsExported() && field.PkgPath != home {
+				continue
+			}
+			stop := shrinkValue(settableField(v, i), home, func(e reflect.Value) bool {
+				return yield(with(func(c reflect.Value) { settableField(c, i).Set(e) }))
+			})
+			if stop {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// homePackage returns the package of the named type `typ` is, or is a
+// pointer, slice, array or map of.
+func homePackage(typ reflect.Type) string {
+
+	for typ.Name() == "" {
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		default:
+			return ""
+		}
+	}
+	return typ.PkgPath()
+}
+
+// settableField returns field `i` of the addressable struct `v`, including
+// unexported fields, as a settable value.
+func settableField(v reflect.Value, i int) reflect.Value {
+
+	f := v.Field(i)
+	if f.CanSet() {
+		return f
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+func formatPaths(v reflect.Value, path string, lines *[]string) {
+
+	leaf := func(s string) {
+		p := path
+		if p == "" {
+			p = "."
+		}
+		*lines = append(*lines, p+": "+s)
+	}
+
+	if v.Kind() != reflect.Interface && v.Kind() != reflect.Pointer {
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			leaf(s.String())
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			leaf("nil")
+			return
+		}
+		e := v.Elem()
+		if !e.CanAddr() {
+			c := reflect.New(e.Type()).Elem()
+			c.Set(e)
+			e = c
+		}
+		formatPaths(e, path, lines)
+	case reflect.Struct:
+		if v.NumField() == 0 {
+			leaf("{}")
+			return
+		}
+		for i:=0; i<v.NumField(); i++ {
+			formatPaths(settableField(v, i), path+"."+v.Type().Field(i).Name, lines)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			leaf("nil")
+			return
+		}
+		if v.Len() == 0 {
+			leaf("[]")
+			return
+		}
+		for i:=0; i<v.Len(); i++ {
+			formatPaths(v.Index(i), path+fmt.Sprintf(".[%d]", i), lines)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			leaf("nil")
+			return
+		}
+		if v.Len() == 0 {
+			leaf("{}")
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			formatPaths(e, path+fmt.Sprintf(".['%v']", k), lines)
+		}
+	default:
+		leaf(fmt.Sprintf("%#v", v.Interface()))
+	}
+}
@@ -0,0 +1,156 @@
+package rand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+type Priced struct {
+	Price decimal.Decimal
+	At time.Time
+}
+
+type ShrinkStruct struct {
+	Name string
+	Count int
+	Tags []string
+	Attrs map[string]int
+	Nested *MyStruct
+}
+
+func TestShrink(t *testing.T) {
+
+	t.Run("int shrinks to boundary", func(t *testing.T) {
+		actual := rand.Shrink(int64(987654321), func(in int64) bool {
+			return in > 100
+		})
+		require.Equal(t, int64(101), actual)
+	})
+
+	t.Run("negative int shrinks to boundary", func(t *testing.T) {
+		actual := rand.Shrink(-987654321, func(in int) bool {
+			return in < -7
+		})
+		require.Equal(t, -8, actual)
+	})
+
+	t.Run("uint shrinks to boundary", func(t *testing.T) {
+		actual := rand.Shrink(uint16(60000), func(in uint16) bool {
+			return in >= 3
+		})
+		require.Equal(t, uint16(3), actual)
+	})
+
+	t.Run("string shrinks to shortest failing length", func(t *testing.T) {
+		actual := rand.Shrink("9c5375c2675d0ec0", func(in string) bool {
+			return len(in) >= 3
+		})
+		require.Len(t, actual, 3)
+	})
+
+	t.Run("slice shrinks length and elements", func(t *testing.T) {
+		actual := rand.Shrink([]int{5, 9, 1, 7, 3}, func(in []int) bool {
+			return len(in) >= 2
+		})
+		require.Equal(t, []int{0, 0}, actual)
+	})
+
+	t.Run("map shrinks entries and values", func(t *testing.T) {
+		actual := rand.Shrink(map[string]int{"a": 1, "b": 2, "c": 3}, func(in map[string]int) bool {
+			return len(in) >= 2
+		})
+		require.Len(t, actual, 2)
+		for _, v := range actual {
+			require.Equal(t, 0, v)
+		}
+	})
+
+	t.Run("struct shrinks to only the failing field", func(t *testing.T) {
+		in := rand.NewFromSeed[ShrinkStruct](t, 1234)
+		actual := rand.Shrink(in, func(in ShrinkStruct) bool {
+			return in.Nested != nil && in.Count > 10
+		})
+		require.Equal(t, ShrinkStruct{
+			Count: 11,
+			Nested: &MyStruct{},
+		}, actual)
+	})
+
+	t.Run("unexported fields are shrunk", func(t *testing.T) {
+		actual := rand.Shrink(MyStruct{Exported: "abc", unexported: "def"}, func(in MyStruct) bool {
+			return in.Exported != ""
+		})
+		require.Equal(t, MyStruct{Exported: "a"}, actual)
+	})
+
+	t.Run("structs in map values are shrunk", func(t *testing.T) {
+		type Counter struct {
+			A int
+		}
+		actual := rand.Shrink(map[string]Counter{"k": {A: 10}}, func(in map[string]Counter) bool {
+			return in["k"].A > 3
+		})
+		require.Equal(t, map[string]Counter{"k": {A: 4}}, actual)
+	})
+
+	t.Run("structs in interfaces are shrunk", func(t *testing.T) {
+		actual := rand.Shrink([]any{MyStruct{Exported: "abcdef"}}, func(in []any) bool {
+			if len(in) == 0 {
+				return false
+			}
+			s, ok := in[0].(MyStruct)
+			return ok && len(s.Exported) > 2
+		})
+		require.Equal(t, []any{MyStruct{Exported: "abc"}}, actual)
+	})
+
+	t.Run("unexported fields of other packages are not shrunk", func(t *testing.T) {
+		price := decimal.RequireFromString("12.5")
+		at := time.Date(2024, 2, 3, 4, 5, 6, 7, time.UTC)
+		actual := rand.Shrink(Priced{Price: price, At: at}, func(in Priced) bool {
+			return in.Price.GreaterThan(decimal.NewFromInt(1)) && !in.At.IsZero()
+		})
+		require.True(t, price.Equal(actual.Price), actual.Price.String())
+		require.True(t, at.Equal(actual.At), actual.At.String())
+	})
+
+	t.Run("value which passes is returned unchanged", func(t *testing.T) {
+		in := rand.NewFromSeed[ShrinkStruct](t, 1234)
+		actual := rand.Shrink(in, func(in ShrinkStruct) bool {
+			return false
+		})
+		require.Equal(t, in, actual)
+	})
+}
+
+func TestFormatPaths(t *testing.T) {
+
+	in := ShrinkStruct{
+		Name: "abc",
+		Tags: []string{"x", "y"},
+		Attrs: map[string]int{"b": 2, "a": 1},
+		Nested: &MyStruct{Exported: "e", unexported: "u"},
+	}
+
+	expected := `.Name: "abc"
+.Count: 0
+.Tags.[0]: "x"
+.Tags.[1]: "y"
+.Attrs.['a']: 1
+.Attrs.['b']: 2
+.Nested.Exported: "e"
+.Nested.unexported: "u"`
+
+	require.Equal(t, expected, rand.FormatPaths(in))
+	require.Equal(t, ".: 12", rand.FormatPaths(12))
+	require.Equal(t, ".Tags: nil\n.Attrs: nil\n.Nested: nil", rand.FormatPaths(struct{
+		Tags []string
+		Attrs map[string]int
+		Nested *MyStruct
+	}{}))
+}
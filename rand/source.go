@@ -0,0 +1,83 @@
+package rand
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"io"
+	"math/rand"
+)
+
+// Source is a source of uniformly distributed random values. It is satisfied
+// by the sources of `math/rand/v2`, such as `rand.NewPCG(seed1, seed2)` and
+// `rand.NewChaCha8(seed)`, and by the sources returned by
+// `math/rand.NewSource`.
+type Source interface {
+	Uint64() uint64
+}
+
+// WithSource generates values from `src` instead of from a `math/rand`
+// source seeded by the time or by the seed passed to a `...FromSeed`
+// function, which is then ignored.
+func WithSource(src Source) Option {
+	return func(o *options) {
+		o.source = src
+	}
+}
+
+// ReaderSource returns a source which reads its values from `r`, eight bytes
+// at a time; once `r` is exhausted, or returns an error, the values are
+// padded with zeros.
+func ReaderSource(r io.Reader) Source {
+	return &readerSource{r: r}
+}
+
+// CryptoSource returns a source backed by `crypto/rand`, for values which
+// must not be predictable; such values can never be reproduced.
+func CryptoSource() Source {
+	return ReaderSource(crand.Reader)
+}
+
+// newRand returns the stream to generate values from; `o.source` if one was
+// given, otherwise a stream seeded by `seed`.
+func newRand(o *options, seed int64) *rand.Rand {
+
+	if o.source == nil {
+		o.stableSeed = seed
+		return rand.New(rand.NewSource(seed))
+	}
+
+	r := rand.New(&source64{src: o.source})
+	if o.stable {
+		o.stableSeed = r.Int63()
+	}
+	return r
+}
+
+type readerSource struct {
+	r io.Reader
+}
+
+func (s *readerSource) Uint64() uint64 {
+
+	var buf [8]byte
+	io.ReadFull(s.r, buf[:])
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// source64 adapts a `Source` to the `rand.Source64` of `math/rand`.
+type source64 struct {
+	src Source
+}
+
+func (s *source64) Uint64() uint64 {
+	return s.src.Uint64()
+}
+
+// Int63 masks the top bit, as the sources of `math/rand` do, so that a
+// `math/rand` source produces the same values when passed to `WithSource`.
+func (s *source64) Int63() int64 {
+	return int64(s.src.Uint64() & (1<<63 - 1))
+}
+
+func (s *source64) Seed(seed int64) {
+}
@@ -0,0 +1,30 @@
+//go:build go1.22
+
+package rand_test
+
+import (
+	randv2 "math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+func TestWithMathRandV2Source(t *testing.T) {
+
+	t.Run("pcg with 128 bit seed is reproducible", func(t *testing.T) {
+		a := rand.New[Order](t, rand.WithSource(randv2.NewPCG(1, 2)))
+		b := rand.New[Order](t, rand.WithSource(randv2.NewPCG(1, 2)))
+		c := rand.New[Order](t, rand.WithSource(randv2.NewPCG(1, 3)))
+		require.Equal(t, a, b)
+		require.NotEqual(t, a, c)
+	})
+
+	t.Run("chacha8 is reproducible", func(t *testing.T) {
+		seed := [32]byte{1, 2, 3}
+		a := rand.New[Order](t, rand.WithSource(randv2.NewChaCha8(seed)))
+		b := rand.New[Order](t, rand.WithSource(randv2.NewChaCha8(seed)))
+		require.Equal(t, a, b)
+	})
+}
@@ -0,0 +1,68 @@
+package rand_test
+
+import (
+	"bytes"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+func TestWithSource(t *testing.T) {
+
+	t.Run("source is reproducible", func(t *testing.T) {
+		a := rand.New[Order](t, rand.WithSource(&splitMix64{state: 1}))
+		b := rand.New[Order](t, rand.WithSource(&splitMix64{state: 1}))
+		c := rand.New[Order](t, rand.WithSource(&splitMix64{state: 2}))
+		require.Equal(t, a, b)
+		require.NotEqual(t, a, c)
+	})
+
+	t.Run("source overrides seed", func(t *testing.T) {
+		a := rand.NewFromSeed[Order](t, 1, rand.WithSource(&splitMix64{state: 1}))
+		b := rand.NewFromSeed[Order](t, 2, rand.WithSource(&splitMix64{state: 1}))
+		require.Equal(t, a, b)
+	})
+
+	t.Run("math/rand source matches seed", func(t *testing.T) {
+		expected := rand.NewFromSeed[Order](t, 1234)
+		actual := rand.New[Order](t, rand.WithSource(mathrand.NewSource(1234).(mathrand.Source64)))
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("crypto source", func(t *testing.T) {
+		a := rand.New[[4]uint64](t, rand.WithSource(rand.CryptoSource()))
+		b := rand.New[[4]uint64](t, rand.WithSource(rand.CryptoSource()))
+		require.NotEqual(t, a, b)
+	})
+
+	t.Run("reader source matches from bytes", func(t *testing.T) {
+		data := []byte("some entropy which is provided by the user")
+		expected := rand.FromBytes[Order](t, data)
+		actual := rand.New[Order](t, rand.WithSource(rand.ReaderSource(bytes.NewReader(data))))
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("stable mode with source", func(t *testing.T) {
+		v1 := rand.New[AccountV1](t, rand.Stable(), rand.WithSource(&splitMix64{state: 5}))
+		v2 := rand.New[AccountV2](t, rand.Stable(), rand.WithSource(&splitMix64{state: 5}))
+		require.Equal(t, v1.Name, v2.Name)
+		require.Equal(t, v1.Owner, v2.Owner)
+	})
+}
+
+// splitMix64 is a seeded source other than those of `math/rand`.
+type splitMix64 struct {
+	state uint64
+}
+
+func (s *splitMix64) Uint64() uint64 {
+
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,91 @@
+package rand_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+type AccountV1 struct {
+	Name string
+	Balance int64
+	Tags []string
+	Owner MyStruct
+}
+
+// AccountV2 is AccountV1 with a field added and the fields reordered
+type AccountV2 struct {
+	Owner MyStruct
+	Email string
+	Tags []string
+	Balance int64
+	Name string
+}
+
+func TestStable(t *testing.T) {
+
+	t.Run("adding and reordering fields leaves other fields unchanged", func(t *testing.T) {
+		for seed := int64(0); seed < 20; seed++ {
+			v1 := rand.NewFromSeed[AccountV1](t, seed, rand.Stable())
+			v2 := rand.NewFromSeed[AccountV2](t, seed, rand.Stable())
+
+			require.Equal(t, v1.Name, v2.Name)
+			require.Equal(t, v1.Balance, v2.Balance)
+			require.Equal(t, v1.Tags, v2.Tags)
+			require.Equal(t, v1.Owner, v2.Owner)
+		}
+	})
+
+	t.Run("without stable adding fields changes other fields", func(t *testing.T) {
+		v1 := rand.NewFromSeed[AccountV1](t, 1234)
+		v2 := rand.NewFromSeed[AccountV2](t, 1234)
+		require.NotEqual(t, v1.Name, v2.Name)
+	})
+
+	t.Run("different seeds give different values", func(t *testing.T) {
+		a := rand.NewFromSeed[AccountV1](t, 1, rand.Stable())
+		b := rand.NewFromSeed[AccountV1](t, 2, rand.Stable())
+		require.NotEqual(t, a, b)
+	})
+
+	t.Run("fields with the same type get different values", func(t *testing.T) {
+		actual := rand.NewFromSeed[MyStruct](t, 1234, rand.Stable())
+		require.NotEqual(t, actual.Exported, actual.unexported)
+	})
+
+	t.Run("unique slice elements", func(t *testing.T) {
+		actual := rand.NewSliceFromSeed[OrderItem](t, 5, 1234,
+			rand.Stable(),
+			rand.With(func(i *OrderItem) { i.Quantity %= 6 }),
+			rand.UniqueBy(func(i OrderItem) int { return i.Quantity }),
+		)
+		require.Len(t, actual, 5)
+	})
+
+	t.Run("struct map keys", func(t *testing.T) {
+		rand.NewFromSeed[map[MyStruct]int](t, 1234, rand.Stable())
+	})
+
+	// Values generated in stable mode are part of the compatibility guarantee
+	// of this package; if this test needs changing then golden files
+	// generated by users in stable mode will break.
+	t.Run("values are stable across versions", func(t *testing.T) {
+		rand.SetMaxContainerSize(t, 5)
+		actual := rand.NewFromSeed[AccountV1](t, 1234, rand.Stable())
+		require.Equal(t, AccountV1{
+			Name: "99c89189c018b16e",
+			Balance: 2027031699990614108,
+			Tags: []string{
+				"68a48d529bb0a3a",
+				"fb894346d96f8562",
+				"da7fc6928c2693a7",
+			},
+			Owner: MyStruct{
+				Exported: "4c42d4000f8e9b9b",
+				unexported: "6e8d2406b66e210a",
+			},
+		}, actual)
+	})
+}
@@ -0,0 +1,147 @@
+package rand
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Calls records the calls made to the funcs generated by this package when
+// passed to `RecordCalls`.
+//
+// Generated funcs may be called from any goroutine, but must not outlive the
+// test they were generated for: failures to generate their results are only
+// reported while it is running.
+type Calls struct {
+	mu sync.Mutex
+	calls []Call
+}
+
+type Call struct {
+	// Path is the location of the called func within the generated value, in
+	// the notation used by `FormatPaths`.
+	Path string
+	Args []any
+	Results []any
+}
+
+// RecordCalls records every call to a generated func in `calls`.
+func RecordCalls(calls *Calls) Option {
+	return func(o *options) {
+		o.calls = calls
+	}
+}
+
+// All returns every recorded call, in the order they were made.
+func (c *Calls) All() []Call {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// To returns the recorded calls to the func at `path`, e.g. ".OnEvent".
+func (c *Calls) To(path string) []Call {
+
+	var calls []Call
+	for _, call := range c.All() {
+		if call.Path == path {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (c *Calls) record(path string, args, results []reflect.Value) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{
+		Path: path,
+		Args: interfaces(args),
+		Results: interfaces(results),
+	})
+}
+
+// stubT reports failures to generate the results of a stub func with
+// `Errorf`, as the func may be called from any goroutine, and drops them once
+// the test has finished.
+type stubT struct {
+	testing.TB
+	finished *atomic.Bool
+}
+
+func (t stubT) Errorf(format string, args ...any) {
+
+	if t.finished.Load() {
+		return
+	}
+	t.TB.Errorf(format, args...)
+}
+
+func (t stubT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+}
+
+func (t stubT) FailNow() {
+}
+
+// stubFunc returns a func of type `typ` which returns newly generated values
+// of its result types on every call; results of interface types, such as
+// `error`, are nil.
+//
+// Failures to generate results are reported to `t` without stopping the
+// caller. Stubs must not outlive the test: failures are not reported once it
+// has finished.
+func stubFunc(
+	t testing.TB,
+	typ reflect.Type,
+	seed int64,
+	o *options,
+	path string,
+) reflect.Value {
+
+	finished := new(atomic.Bool)
+	t.Cleanup(func() {
+		finished.Store(true)
+	})
+	st := stubT{
+		TB: t,
+		finished: finished,
+	}
+
+	var mu sync.Mutex
+	r := rand.New(rand.NewSource(seed))
+	if path == "" {
+		path = "."
+	}
+
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+
+		mu.Lock()
+		results := make([]reflect.Value, typ.NumOut())
+		for i := range results {
+			res := reflect.New(typ.Out(i)).Elem()
+			fillValue(st, res, r, o, fmt.Sprintf("%s().[%d]", path, i))
+			results[i] = res
+		}
+		mu.Unlock()
+
+		if o.calls != nil {
+			o.calls.record(path, args, results)
+		}
+		return results
+	})
+}
+
+func interfaces(values []reflect.Value) []any {
+
+	is := make([]any, len(values))
+	for i, v := range values {
+		is[i] = v.Interface()
+	}
+	return is
+}
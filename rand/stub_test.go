@@ -0,0 +1,125 @@
+package rand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thecodedproject/gotest/rand"
+)
+
+type BadTag struct {
+	Name string `rand:"not_a_generator"`
+}
+
+type Handlers struct {
+	OnEvent func(name string, n int) (MyStruct, error)
+	Notify func(string)
+	Ids func(prefix string, ids ...int) []int64
+}
+
+func TestFuncs(t *testing.T) {
+
+	t.Run("generated funcs return generated results and nil errors", func(t *testing.T) {
+		h := rand.NewFromSeed[Handlers](t, 1234)
+		require.NotNil(t, h.OnEvent)
+		require.NotNil(t, h.Notify)
+		require.NotNil(t, h.Ids)
+
+		s, err := h.OnEvent("a", 1)
+		require.NoError(t, err)
+		require.NotEmpty(t, s.Exported)
+		require.NotEmpty(t, s.unexported)
+
+		s2, _ := h.OnEvent("a", 1)
+		require.NotEqual(t, s, s2)
+
+		require.NotEmpty(t, h.Ids("x", 1, 2))
+		h.Notify("hello")
+	})
+
+	t.Run("generated funcs are deterministic from seed", func(t *testing.T) {
+		a := rand.NewFromSeed[Handlers](t, 1234)
+		b := rand.NewFromSeed[Handlers](t, 1234)
+
+		aRes, _ := a.OnEvent("x", 1)
+		bRes, _ := b.OnEvent("y", 2)
+		require.Equal(t, aRes, bRes)
+	})
+
+	t.Run("generated funcs report failures without stopping the caller", func(t *testing.T) {
+		rec := &recordingTB{}
+		h := rand.NewFromSeed[struct{
+			Make func() BadTag
+		}](rec, 1234)
+		require.Empty(t, rec.errors)
+
+		returned := make(chan struct{})
+		go func() {
+			h.Make()
+			close(returned)
+		}()
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			require.Fail(t, "generated func did not return")
+		}
+		require.Len(t, rec.errors, 1)
+		require.Contains(t, rec.errors[0], "unknown generator tag")
+
+		rec.finish()
+		h.Make()
+		require.Len(t, rec.errors, 1)
+	})
+
+	t.Run("existing funcs are not replaced", func(t *testing.T) {
+		h := Handlers{
+			Notify: func(string) {
+				panic("called existing func")
+			},
+		}
+		rand.Fill(t, &h)
+		require.Panics(t, func() { h.Notify("") })
+	})
+
+	t.Run("record calls", func(t *testing.T) {
+		var calls rand.Calls
+		h := rand.New[Handlers](t, rand.RecordCalls(&calls))
+
+		res, err := h.OnEvent("first", 1)
+		h.Notify("hello")
+		h.OnEvent("second", 2)
+		ids := h.Ids("p", 3, 4)
+
+		all := calls.All()
+		require.Len(t, all, 4)
+		require.Equal(t, rand.Call{
+			Path: ".OnEvent",
+			Args: []any{"first", 1},
+			Results: []any{res, err},
+		}, all[0])
+		require.Equal(t, rand.Call{
+			Path: ".Notify",
+			Args: []any{"hello"},
+			Results: []any{},
+		}, all[1])
+		require.Equal(t, rand.Call{
+			Path: ".Ids",
+			Args: []any{"p", []int{3, 4}},
+			Results: []any{ids},
+		}, all[3])
+
+		onEvent := calls.To(".OnEvent")
+		require.Len(t, onEvent, 2)
+		require.Equal(t, []any{"second", 2}, onEvent[1].Args)
+		require.Empty(t, calls.To(".Missing"))
+	})
+
+	t.Run("top level func", func(t *testing.T) {
+		var calls rand.Calls
+		f := rand.New[func() int](t, rand.RecordCalls(&calls))
+		f()
+		require.Len(t, calls.To("."), 1)
+	})
+}
@@ -0,0 +1,161 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	gotime "time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/time"
+)
+
+var (
+	update = flag.Bool("snapshot.update", false, "update the golden files of gotest/snapshot instead of comparing against them")
+
+	timestampRegex = regexp.MustCompile(
+		`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`,
+	)
+)
+
+type Option func(*options)
+
+type options struct {
+	name string
+	scrubbers []func([]byte) []byte
+}
+
+// Name sets the name of the golden file, `testdata/<name>.golden`, which
+// defaults to the name of the test.
+func Name(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// Scrub replaces every match of `re` with `replacement` before comparing.
+func Scrub(re *regexp.Regexp, replacement string) Option {
+	return func(o *options) {
+		o.scrubbers = append(o.scrubbers, func(b []byte) []byte {
+			return re.ReplaceAll(b, []byte(replacement))
+		})
+	}
+}
+
+// ScrubTimes replaces every RFC 3339 timestamp with `<time>` before
+// comparing.
+func ScrubTimes() Option {
+	return Scrub(timestampRegex, "<time>")
+}
+
+// ScrubNow replaces the current time of the gotest `time` package, in RFC
+// 3339 format, with `<now>` before comparing; use it together with
+// `time.SetTimeNowForTesting` to scrub only the timestamps the code under
+// test took from `time.Now`.
+func ScrubNow() Option {
+	return func(o *options) {
+		o.scrubbers = append(o.scrubbers, func(b []byte) []byte {
+			now := time.Now()
+			for _, layout := range []string{gotime.RFC3339Nano, gotime.RFC3339} {
+				for _, ts := range []gotime.Time{now, now.UTC()} {
+					b = bytes.ReplaceAll(b, []byte(ts.Format(layout)), []byte("<now>"))
+				}
+			}
+			return b
+		})
+	}
+}
+
+// Match compares `got` against the golden file `testdata/<TestName>.golden`,
+// or writes `got` to it when the tests are run with `-snapshot.update`.
+//
+// Strings and byte slices are compared as they are; any other value is
+// serialised as indented JSON, which sorts map keys and normalises decimals,
+// so that logically equal values serialise the same. The golden file is
+// compared byte for byte, so a stale or reformatted golden file fails until
+// it is updated. On mismatch a unified diff of the golden file and `got` is
+// reported.
+func Match(t *testing.T, got any, opts ...Option) bool {
+
+	t.Helper()
+
+	o := &options{
+		name: t.Name(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	gotBytes, err := serialise(got)
+	if err != nil {
+		return tfyassert.Fail(t, fmt.Sprintf("gotest/snapshot: cannot serialise value: %v", err))
+	}
+	for _, scrub := range o.scrubbers {
+		gotBytes = scrub(gotBytes)
+	}
+
+	path := filepath.Join("testdata", filepath.FromSlash(o.name) + ".golden")
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, gotBytes, 0o644)
+		}
+		if err != nil {
+			return tfyassert.Fail(t, fmt.Sprintf("gotest/snapshot: cannot update golden file: %v", err))
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tfyassert.Fail(t, fmt.Sprintf(
+			"gotest/snapshot: golden file %s does not exist; run the test with -snapshot.update to create it",
+			path,
+		))
+	} else if err != nil {
+		return tfyassert.Fail(t, fmt.Sprintf("gotest/snapshot: cannot read golden file: %v", err))
+	}
+
+	if bytes.Equal(want, gotBytes) {
+		return true
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A: difflib.SplitLines(string(want)),
+		B: difflib.SplitLines(string(gotBytes)),
+		FromFile: path,
+		ToFile: "got",
+		Context: 3,
+	})
+	return tfyassert.Fail(t, fmt.Sprintf(
+		"gotest/snapshot: value does not match golden file %s (run the test with -snapshot.update to update it)\n\n%s",
+		path,
+		diff,
+	))
+}
+
+func serialise(v any) ([]byte, error) {
+
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
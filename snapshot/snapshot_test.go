@@ -0,0 +1,113 @@
+package snapshot_test
+
+import (
+	"flag"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/snapshot"
+	testtime "github.com/thecodedproject/gotest/time"
+)
+
+type Invoice struct {
+	ID string
+	Total decimal.Decimal
+	Lines map[string]int
+	CreatedAt time.Time
+}
+
+func TestMatch(t *testing.T) {
+
+	t.Run("string", func(t *testing.T) {
+		snapshot.Match(t, "SELECT *\nFROM invoices\nWHERE id = $1\n")
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		snapshot.Match(t, []byte(`{"b":1,"a":2}`))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		snapshot.Match(t, Invoice{
+			ID: "inv-1",
+			Total: decimal.RequireFromString("12.50"),
+			Lines: map[string]int{"b": 2, "a": 1, "c": 3},
+			CreatedAt: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		})
+	})
+
+	t.Run("decimals are normalised", func(t *testing.T) {
+		snapshot.Match(t, decimal.New(1250, -2), snapshot.Name("TestMatch/decimal"))
+		snapshot.Match(t, decimal.New(125, -1), snapshot.Name("TestMatch/decimal"))
+	})
+
+	t.Run("scrub times", func(t *testing.T) {
+		snapshot.Match(t, Invoice{
+			ID: "inv-2",
+			CreatedAt: time.Now(),
+		}, snapshot.ScrubTimes())
+	})
+
+	t.Run("scrub now", func(t *testing.T) {
+		now := testtime.SetTimeNowForTesting(t)
+		snapshot.Match(t, map[string]time.Time{
+			"created": now,
+			"due": time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		}, snapshot.ScrubNow())
+	})
+
+	t.Run("scrub", func(t *testing.T) {
+		snapshot.Match(t, "request id: 8f14e45f", snapshot.Scrub(regexp.MustCompile(`[0-9a-f]{8}`), "<id>"))
+	})
+}
+
+func TestMatchFails(t *testing.T) {
+
+	t.Run("mismatch", func(t *testing.T) {
+		var fakeT testing.T
+		tfyassert.False(t, snapshot.Match(&fakeT, "something else", snapshot.Name("TestMatch/string")))
+		tfyassert.True(t, fakeT.Failed())
+	})
+
+	t.Run("struct mismatch", func(t *testing.T) {
+		var fakeT testing.T
+		tfyassert.False(t, snapshot.Match(&fakeT, Invoice{ID: "inv-1"}, snapshot.Name("TestMatch/struct")))
+		tfyassert.True(t, fakeT.Failed())
+	})
+
+	t.Run("stale golden file with a removed field", func(t *testing.T) {
+		var fakeT testing.T
+		tfyassert.False(t, snapshot.Match(&fakeT, Invoice{
+			ID: "inv-1",
+			Total: decimal.RequireFromString("12.50"),
+			Lines: map[string]int{"b": 2, "a": 1, "c": 3},
+			CreatedAt: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		}, snapshot.Name(t.Name())))
+		tfyassert.True(t, fakeT.Failed())
+	})
+
+	t.Run("reformatted golden file", func(t *testing.T) {
+		var fakeT testing.T
+		tfyassert.False(t, snapshot.Match(&fakeT, map[string]int{"a": 1, "b": 2}, snapshot.Name(t.Name())))
+		tfyassert.True(t, fakeT.Failed())
+	})
+
+	t.Run("missing golden file", func(t *testing.T) {
+		var fakeT testing.T
+		tfyassert.False(t, snapshot.Match(&fakeT, "value", snapshot.Name("does_not_exist")))
+		tfyassert.True(t, fakeT.Failed())
+	})
+}
+
+// update is the flag name commonly defined by tests with golden files of
+// their own, which must not clash with the flag of this package.
+var update = flag.Bool("update", false, "update the golden files of this test")
+
+func TestUpdateFlagIsNamespaced(t *testing.T) {
+
+	tfyassert.NotNil(t, flag.Lookup("snapshot.update"))
+	tfyassert.False(t, *update)
+}
@@ -0,0 +1,70 @@
+package spy
+
+import (
+	"fmt"
+	"sync"
+
+	tfymock "github.com/stretchr/testify/mock"
+)
+
+// Captor captures the arguments of calls to a testify mock; its zero value
+// is ready to use.
+type Captor[T any] struct {
+	mu sync.Mutex
+	values []T
+}
+
+// Arg returns a func to pass to `mock.Call.Run` which captures argument `i`
+// of each call, e.g. `store.On("Put", mock.Anything).Run(captor.Arg(0))`; the
+// call must be set up on the mock which is called, so not with `gotest.On`.
+func (c *Captor[T]) Arg(i int) func(tfymock.Arguments) {
+
+	return func(args tfymock.Arguments) {
+
+		var value T
+		if arg := args.Get(i); arg != nil {
+			v, ok := arg.(T)
+			if !ok {
+				panic(fmt.Sprintf("gotest/spy: cannot capture argument %d of type %T as %T", i, arg, value))
+			}
+			value = v
+		}
+
+		c.mu.Lock()
+		c.values = append(c.values, value)
+		c.mu.Unlock()
+	}
+}
+
+// Values returns the captured values, in the order they were captured.
+func (c *Captor[T]) Values() []T {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]T(nil), c.values...)
+}
+
+// Last returns the last captured value, or the zero value if none were
+// captured.
+func (c *Captor[T]) Last() T {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var last T
+	if len(c.values) > 0 {
+		last = c.values[len(c.values)-1]
+	}
+	return last
+}
+
+// Capture combines the funcs returned by `Captor.Arg`, as `mock.Call.Run`
+// takes only one, e.g. `Run(spy.Capture(ids.Arg(0), amounts.Arg(1)))`.
+func Capture(captures ...func(tfymock.Arguments)) func(tfymock.Arguments) {
+
+	return func(args tfymock.Arguments) {
+		for _, capture := range captures {
+			capture(args)
+		}
+	}
+}
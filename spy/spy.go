@@ -0,0 +1,158 @@
+package spy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tfyassert "github.com/stretchr/testify/assert"
+
+	"github.com/thecodedproject/gotest/assert"
+)
+
+// Spy records the calls made to a func wrapped by `Func`.
+type Spy struct {
+	name string
+
+	mu sync.Mutex
+	calls []Call
+}
+
+// Call is a recorded call; the arguments of variadic funcs are recorded as
+// called, with the variadic arguments as a slice.
+type Call struct {
+	Args []any
+	Results []any
+
+	seq uint64
+}
+
+// seq orders the calls of every spy, for `CalledInOrder`.
+var seq atomic.Uint64
+
+// Func returns a func which calls `fn` and records each call in the returned
+// spy; `fn` must be a non-nil func.
+func Func[F any](t *testing.T, fn F) (F, *Spy) {
+
+	t.Helper()
+
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		require.Fail(t, fmt.Sprintf("gotest/spy: cannot spy on %T, which is not a non-nil func", fn))
+	}
+
+	s := &Spy{
+		name: fmt.Sprintf("%T", fn),
+	}
+	wrapped := reflect.MakeFunc(v.Type(), func(args []reflect.Value) []reflect.Value {
+
+		var results []reflect.Value
+		if v.Type().IsVariadic() {
+			results = v.CallSlice(args)
+		} else {
+			results = v.Call(args)
+		}
+		s.record(args, results)
+		return results
+	})
+	return wrapped.Interface().(F), s
+}
+
+// Named sets the name of `s` used in failure messages and returns `s`.
+func (s *Spy) Named(name string) *Spy {
+
+	s.name = name
+	return s
+}
+
+// Calls returns the recorded calls, in the order they were made.
+func (s *Spy) Calls() []Call {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+func (s *Spy) record(args, results []reflect.Value) {
+
+	c := Call{
+		Args: interfaces(args),
+		Results: interfaces(results),
+		seq: seq.Add(1),
+	}
+	s.mu.Lock()
+	s.calls = append(s.calls, c)
+	s.mu.Unlock()
+}
+
+// CalledTimes asserts that the func spied on by `s` was called `n` times.
+func CalledTimes(t *testing.T, s *Spy, n int, msgs ...any) bool {
+
+	t.Helper()
+
+	calls := len(s.Calls())
+	if calls == n {
+		return true
+	}
+	return tfyassert.Fail(t, fmt.Sprintf("%s called %d time(s), expected %d", s.name, calls, n), msgs...)
+}
+
+// CalledWith asserts that the func spied on by `s` was called at least once
+// with arguments logically equal to `args`, as compared by
+// `assert.LogicallyEqual`, e.g. `CalledWith(t, s, []any{"acc", amount})`.
+func CalledWith(t *testing.T, s *Spy, args []any, msgs ...any) bool {
+
+	t.Helper()
+
+	calls := s.Calls()
+	for _, c := range calls {
+		if assert.IsLogicallyEqual(args, c.Args) {
+			return true
+		}
+	}
+
+	msg := fmt.Sprintf("%s not called with %v", s.name, args)
+	if len(calls) == 0 {
+		msg += "; it was never called"
+	}
+	for i, c := range calls {
+		msg += fmt.Sprintf("\ncall %d: %v", i, c.Args)
+	}
+	return tfyassert.Fail(t, msg, msgs...)
+}
+
+// CalledInOrder asserts that the first call to each of the funcs spied on by
+// `spies` was made in the order given.
+func CalledInOrder(t *testing.T, spies ...*Spy) bool {
+
+	t.Helper()
+
+	var last uint64
+	for i, s := range spies {
+		calls := s.Calls()
+		if len(calls) == 0 {
+			return tfyassert.Fail(t, fmt.Sprintf("%s was never called", s.name))
+		}
+		if calls[0].seq < last {
+			return tfyassert.Fail(t, fmt.Sprintf(
+				"%s first called before %s",
+				s.name,
+				spies[i-1].name,
+			))
+		}
+		last = calls[0].seq
+	}
+	return true
+}
+
+func interfaces(values []reflect.Value) []any {
+
+	is := make([]any, len(values))
+	for i, v := range values {
+		is[i] = v.Interface()
+	}
+	return is
+}
@@ -0,0 +1,132 @@
+package spy_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	tfyassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tfymock "github.com/stretchr/testify/mock"
+
+	"github.com/thecodedproject/gotest/spy"
+	gotest "github.com/thecodedproject/gotest/util"
+)
+
+type Ledger struct {
+	tfymock.Mock
+}
+
+func (l *Ledger) Post(account string, amount decimal.Decimal) error {
+	return l.Called(account, amount).Error(0)
+}
+
+func TestFunc(t *testing.T) {
+
+	add, s := spy.Func(t, func(a, b decimal.Decimal) decimal.Decimal {
+		return a.Add(b)
+	})
+
+	require.True(t, add(gotest.DS("1"), gotest.DS("2")).Equal(gotest.DS("3")))
+	add(gotest.DS("0.5"), gotest.DS("0.5"))
+
+	calls := s.Calls()
+	require.Len(t, calls, 2)
+	require.Len(t, calls[0].Args, 2)
+	require.True(t, calls[1].Results[0].(decimal.Decimal).Equal(gotest.DS("1")))
+
+	tfyassert.True(t, spy.CalledTimes(t, s, 2))
+	tfyassert.True(t, spy.CalledWith(t, s, []any{gotest.DS("1.0"), gotest.DS("2.00")}))
+
+	var fakeT testing.T
+	tfyassert.False(t, spy.CalledTimes(&fakeT, s, 1))
+	tfyassert.False(t, spy.CalledWith(&fakeT, s, []any{gotest.DS("1"), gotest.DS("1")}))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestCalledWithMessage(t *testing.T) {
+
+	post, s := spy.Func(t, func(account string) {})
+	post("acc-1")
+
+	tfyassert.True(t, spy.CalledWith(t, s, []any{"acc-1"}, "posting %s", "acc-1"))
+
+	var fakeT testing.T
+	tfyassert.False(t, spy.CalledWith(&fakeT, s, []any{"acc-2"}, "posting %s", "acc-2"))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestFuncVariadic(t *testing.T) {
+
+	join, s := spy.Func(t, func(sep string, parts ...string) string {
+		return strings.Join(parts, sep)
+	})
+
+	require.Equal(t, "a-b", join("-", "a", "b"))
+	require.Equal(t, "", join("-"))
+
+	tfyassert.True(t, spy.CalledWith(t, s, []any{"-", []string{"a", "b"}}))
+	tfyassert.True(t, spy.CalledTimes(t, s, 2))
+}
+
+func TestFuncFailsForNonFuncs(t *testing.T) {
+
+	var fakeT testing.T
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		spy.Func(&fakeT, "not a func")
+	}()
+	<-done
+	require.True(t, fakeT.Failed())
+}
+
+func TestCalledInOrder(t *testing.T) {
+
+	open, openSpy := spy.Func(t, func() {})
+	write, writeSpy := spy.Func(t, func(string) {})
+	closeFn, closeSpy := spy.Func(t, func() error { return nil })
+	closeSpy.Named("close")
+
+	open()
+	write("a")
+	write("b")
+	closeFn()
+
+	tfyassert.True(t, spy.CalledInOrder(t, openSpy, writeSpy, closeSpy))
+
+	var fakeT testing.T
+	tfyassert.False(t, spy.CalledInOrder(&fakeT, closeSpy, openSpy))
+	tfyassert.True(t, fakeT.Failed())
+
+	_, neverSpy := spy.Func(t, func() {})
+	fakeT = testing.T{}
+	tfyassert.False(t, spy.CalledInOrder(&fakeT, openSpy, neverSpy))
+	tfyassert.True(t, fakeT.Failed())
+}
+
+func TestCaptor(t *testing.T) {
+
+	var accounts spy.Captor[string]
+	var amounts spy.Captor[decimal.Decimal]
+
+	var l Ledger
+	l.On("Post", tfymock.Anything, tfymock.Anything).
+		Run(spy.Capture(accounts.Arg(0), amounts.Arg(1))).
+		Return(nil)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Post(fmt.Sprintf("acc-%d", i), gotest.DI(int64(i), -1)))
+	}
+
+	require.Equal(t, []string{"acc-0", "acc-1", "acc-2"}, accounts.Values())
+	require.True(t, amounts.Last().Equal(gotest.DS("0.2")))
+
+	var none spy.Captor[error]
+	none.Arg(0)(tfymock.Arguments{nil})
+	require.Equal(t, []error{nil}, none.Values())
+	require.Panics(t, func() {
+		none.Arg(0)(tfymock.Arguments{"not an error"})
+	})
+}
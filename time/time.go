@@ -1,6 +1,7 @@
 package time
 
 import (
+	"sync"
 	"testing"
 	gotime "time"
 )
@@ -14,7 +15,10 @@ const (
 	Hour = gotime.Hour
 )
 
-var nowFunc = gotime.Now
+var (
+	nowFunc = gotime.Now
+	sleepFunc = gotime.Sleep
+)
 
 func Now() gotime.Time {
 
@@ -40,3 +44,40 @@ func SetTimeNowForTesting(t *testing.T) (now gotime.Time) {
 
 	return now
 }
+
+func Sleep(d gotime.Duration) {
+
+	sleepFunc(d)
+}
+
+func SetSleepFuncForTesting(t *testing.T, sleep func(gotime.Duration)) {
+
+	oldSleepFunc := sleepFunc
+	sleepFunc = sleep
+	t.Cleanup(func() {
+		sleepFunc = oldSleepFunc
+	})
+}
+
+// SetFakeClockForTesting sets `Now` to return a time which only moves
+// forward when `Sleep` is called, which returns without waiting.
+func SetFakeClockForTesting(t *testing.T) (start gotime.Time) {
+
+	var mu sync.Mutex
+	now := gotime.Now()
+
+	SetTimeNowFuncForTesting(t, func() gotime.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	})
+	SetSleepFuncForTesting(t, func(d gotime.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		if d > 0 {
+			now = now.Add(d)
+		}
+	})
+
+	return now
+}
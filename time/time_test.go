@@ -31,3 +31,26 @@ func TestTimeNowWithoutMocking(t *testing.T) {
 	diff := time.Now().Sub(now)
 	assert.True(t, diff < 5*time.Millisecond)
 }
+
+func TestSetSleepFunc(t *testing.T) {
+
+	var slept []time.Duration
+	testtime.SetSleepFuncForTesting(t, func(d time.Duration) {
+		slept = append(slept, d)
+	})
+	testtime.Sleep(time.Hour)
+	testtime.Sleep(time.Second)
+	assert.Equal(t, []time.Duration{time.Hour, time.Second}, slept)
+}
+
+func TestSetFakeClock(t *testing.T) {
+
+	start := testtime.SetFakeClockForTesting(t)
+	assert.Equal(t, start, testtime.Now())
+
+	testtime.Sleep(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), testtime.Now())
+
+	testtime.Sleep(-time.Minute)
+	assert.Equal(t, start.Add(time.Hour), testtime.Now())
+}
@@ -5,10 +5,47 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// D returns the decimal closest to `v`; as `v` is a float, `D(0.1)` is not
+// exactly 0.1. Use `DS` or `DI` for exact values.
 func D(v float64) decimal.Decimal {
 	return decimal.NewFromFloat(v)
 }
 
+// DS returns the decimal represented by `s`, e.g. `DS("0.1")`, and panics if
+// `s` is not a valid decimal.
+func DS(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// DI returns the decimal `n * 10^exp`, e.g. `DI(125, -2)` is 1.25.
+func DI(n int64, exp int32) decimal.Decimal {
+	return decimal.New(n, exp)
+}
+
+// DSlice returns the decimals represented by `ss`, as `DS` does.
+func DSlice(ss ...string) []decimal.Decimal {
+
+	ds := make([]decimal.Decimal, len(ss))
+	for i, s := range ss {
+		ds[i] = DS(s)
+	}
+	return ds
+}
+
+// DMap returns a map of the decimals represented by the values of `m`, as
+// `DS` does.
+func DMap[Key comparable](m map[Key]string) map[Key]decimal.Decimal {
+
+	ds := make(map[Key]decimal.Decimal, len(m))
+	for k, s := range m {
+		ds[k] = DS(s)
+	}
+	return ds
+}
+
+// On returns a `*mock.Call` which is not attached to any mock held by the
+// caller, for use as a call literal; use `gotest/mock.Expect` to set up
+// expectations on a mock.
 func On(funcName string, args ...interface{}) *mock.Call {
 	return new(mock.Mock).On(funcName, args...)
 }